@@ -0,0 +1,516 @@
+package main
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/github"
+)
+
+// sessionTTL bounds how long an admin UI login is valid before the user must
+// re-authenticate with the OIDC provider.
+const sessionTTL = 12 * time.Hour
+
+// Session is the set of claims carried in the signed session cookie.
+type Session struct {
+	Subject string   `json:"sub"`
+	Email   string   `json:"email"`
+	Groups  []string `json:"groups"`
+}
+
+// CanEdit reports whether the session belongs to a member of the configured
+// edit group. Without an edit group configured, any authenticated session
+// may edit (view/edit authorization collapses to "logged in or not").
+func (s Session) CanEdit(editGroup string) bool {
+	if editGroup == "" {
+		return true
+	}
+	for _, g := range s.Groups {
+		if g == editGroup {
+			return true
+		}
+	}
+	return false
+}
+
+// AuthConfig holds the OAuth2/OIDC settings for the admin web UI, loaded
+// from environment variables. Authorization Code + PKCE is used for every
+// provider. "google" gets its well-known issuer filled in, "gitlab" and
+// "generic" discover theirs from OIDC_ISSUER/.well-known; "github" is a
+// plain OAuth2 provider (github.com has no OIDC discovery endpoint) whose
+// session is built from the GitHub REST API instead of an ID token.
+type AuthConfig struct {
+	Provider     string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	EditGroup    string
+	SessionKey   []byte
+
+	// CookieInsecure omits the Secure attribute from the PKCE/session
+	// cookies. The admin UI is expected to run behind a TLS-terminating
+	// reverse proxy, so AdminServer.Start's plain net/http.ListenAndServe
+	// never sees r.TLS set even in production; only set this for local
+	// development over plain HTTP.
+	CookieInsecure bool
+}
+
+// newAuthConfigFromEnv returns an AuthConfig if OIDC is configured for the
+// admin UI, or nil if it's disabled.
+func newAuthConfigFromEnv() *AuthConfig {
+	clientID := os.Getenv("OIDC_CLIENT_ID")
+	clientSecret := os.Getenv("OIDC_CLIENT_SECRET")
+	if clientID == "" || clientSecret == "" {
+		return nil
+	}
+
+	sessionKey := os.Getenv("SESSION_SECRET")
+	if sessionKey == "" {
+		logger.Println("OIDC is configured but SESSION_SECRET is missing; admin UI auth disabled")
+		return nil
+	}
+
+	return &AuthConfig{
+		Provider:       orDefault(os.Getenv("OIDC_PROVIDER"), "generic"),
+		Issuer:         os.Getenv("OIDC_ISSUER"),
+		ClientID:       clientID,
+		ClientSecret:   clientSecret,
+		RedirectURL:    os.Getenv("OIDC_REDIRECT_URL"),
+		EditGroup:      os.Getenv("ADMIN_EDIT_GROUP"),
+		SessionKey:     []byte(sessionKey),
+		CookieInsecure: os.Getenv("ADMIN_COOKIE_INSECURE") == "true",
+	}
+}
+
+// Authenticator wraps the OIDC provider/verifier and issues/validates
+// signed session cookies. provider/verifier are nil for cfg.Provider ==
+// "github", which has no OIDC discovery or ID tokens to verify.
+type Authenticator struct {
+	cfg      *AuthConfig
+	provider *oidc.Provider
+	verifier *oidc.IDTokenVerifier
+	oauth2   oauth2.Config
+}
+
+func newAuthenticator(ctx context.Context, cfg *AuthConfig) (*Authenticator, error) {
+	if cfg.Provider == "github" {
+		return &Authenticator{
+			cfg: cfg,
+			oauth2: oauth2.Config{
+				ClientID:     cfg.ClientID,
+				ClientSecret: cfg.ClientSecret,
+				RedirectURL:  cfg.RedirectURL,
+				Endpoint:     github.Endpoint,
+				Scopes:       []string{"read:user", "user:email", "read:org"},
+			},
+		}, nil
+	}
+
+	issuer := cfg.Issuer
+	switch cfg.Provider {
+	case "google":
+		issuer = "https://accounts.google.com"
+	case "gitlab":
+		if issuer == "" {
+			issuer = "https://gitlab.com"
+		}
+	}
+	if issuer == "" {
+		return nil, fmt.Errorf("OIDC_ISSUER must be set for provider %q", cfg.Provider)
+	}
+
+	provider, err := oidc.NewProvider(ctx, issuer)
+	if err != nil {
+		return nil, fmt.Errorf("discovering OIDC provider %s: %w", issuer, err)
+	}
+
+	return &Authenticator{
+		cfg:      cfg,
+		provider: provider,
+		verifier: provider.Verifier(&oidc.Config{ClientID: cfg.ClientID}),
+		oauth2: oauth2.Config{
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			RedirectURL:  cfg.RedirectURL,
+			Endpoint:     provider.Endpoint(),
+			Scopes:       []string{oidc.ScopeOpenID, "profile", "email", "groups"},
+		},
+	}, nil
+}
+
+// --- PKCE + state ---------------------------------------------------------
+
+const pkceCookieName = "ssl_monitor_pkce"
+
+type pkceState struct {
+	State    string `json:"state"`
+	Verifier string `json:"verifier"`
+}
+
+func randomToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func codeChallengeS256(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// handleLogin begins the Authorization Code + PKCE flow: it stashes a state
+// nonce and PKCE verifier in a short-lived signed cookie, then redirects to
+// the provider's authorization endpoint.
+func (a *Authenticator) handleLogin(w http.ResponseWriter, r *http.Request) {
+	state, err := randomToken(16)
+	if err != nil {
+		http.Error(w, "failed to start login", http.StatusInternalServerError)
+		return
+	}
+	verifier, err := randomToken(32)
+	if err != nil {
+		http.Error(w, "failed to start login", http.StatusInternalServerError)
+		return
+	}
+
+	signed, err := signValue(a.cfg.SessionKey, pkceState{State: state, Verifier: verifier}, 10*time.Minute)
+	if err != nil {
+		http.Error(w, "failed to start login", http.StatusInternalServerError)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     pkceCookieName,
+		Value:    signed,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   !a.cfg.CookieInsecure,
+		SameSite: http.SameSiteLaxMode,
+		MaxAge:   600,
+	})
+
+	authURL := a.oauth2.AuthCodeURL(state,
+		oauth2.SetAuthURLParam("code_challenge", codeChallengeS256(verifier)),
+		oauth2.SetAuthURLParam("code_challenge_method", "S256"))
+	http.Redirect(w, r, authURL, http.StatusFound)
+}
+
+// handleCallback completes the Authorization Code + PKCE flow: it verifies
+// the state/PKCE cookie, exchanges the code, verifies the ID token, and sets
+// a signed session cookie.
+func (a *Authenticator) handleCallback(w http.ResponseWriter, r *http.Request) {
+	cookie, err := r.Cookie(pkceCookieName)
+	if err != nil {
+		http.Error(w, "missing login state", http.StatusBadRequest)
+		return
+	}
+	var pkce pkceState
+	if err := verifyValue(a.cfg.SessionKey, cookie.Value, &pkce); err != nil {
+		http.Error(w, "invalid or expired login state", http.StatusBadRequest)
+		return
+	}
+	http.SetCookie(w, &http.Cookie{Name: pkceCookieName, Path: "/", MaxAge: -1})
+
+	if subtle.ConstantTimeCompare([]byte(pkce.State), []byte(r.URL.Query().Get("state"))) != 1 {
+		http.Error(w, "state mismatch", http.StatusBadRequest)
+		return
+	}
+
+	token, err := a.oauth2.Exchange(r.Context(), r.URL.Query().Get("code"),
+		oauth2.SetAuthURLParam("code_verifier", pkce.Verifier))
+	if err != nil {
+		http.Error(w, "token exchange failed", http.StatusBadGateway)
+		return
+	}
+
+	var session Session
+	if a.cfg.Provider == "github" {
+		session, err = fetchGitHubSession(r.Context(), a.oauth2.Client(r.Context(), token))
+		if err != nil {
+			http.Error(w, "fetching GitHub user: "+err.Error(), http.StatusBadGateway)
+			return
+		}
+	} else {
+		rawIDToken, ok := token.Extra("id_token").(string)
+		if !ok {
+			http.Error(w, "provider did not return an id_token", http.StatusBadGateway)
+			return
+		}
+
+		idToken, err := a.verifier.Verify(r.Context(), rawIDToken)
+		if err != nil {
+			http.Error(w, "id_token verification failed", http.StatusUnauthorized)
+			return
+		}
+
+		var claims struct {
+			Subject string   `json:"sub"`
+			Email   string   `json:"email"`
+			Groups  []string `json:"groups"`
+		}
+		if err := idToken.Claims(&claims); err != nil {
+			http.Error(w, "invalid id_token claims", http.StatusUnauthorized)
+			return
+		}
+
+		session = Session{
+			Subject: claims.Subject,
+			Email:   claims.Email,
+			Groups:  claims.Groups,
+		}
+	}
+
+	if err := a.setSessionCookie(w, r, session); err != nil {
+		http.Error(w, "failed to start session", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/", http.StatusFound)
+}
+
+// --- GitHub REST API session ----------------------------------------------
+
+// githubUser is the subset of GitHub's "GET /user" response needed to build
+// a Session.
+type githubUser struct {
+	ID    int64  `json:"id"`
+	Email string `json:"email"`
+}
+
+// githubEmail is one entry of GitHub's "GET /user/emails" response,
+// consulted when the primary email is private and /user.Email comes back
+// empty.
+type githubEmail struct {
+	Email    string `json:"email"`
+	Primary  bool   `json:"primary"`
+	Verified bool   `json:"verified"`
+}
+
+// githubOrg is one entry of GitHub's "GET /user/orgs" response.
+type githubOrg struct {
+	Login string `json:"login"`
+}
+
+// fetchGitHubSession builds a Session from the GitHub REST API rather than
+// an ID token: github.com's OAuth app flow doesn't issue one. The user's
+// organizations stand in for the "groups" claim other providers supply, so
+// ADMIN_EDIT_GROUP can hold a GitHub org login.
+func fetchGitHubSession(ctx context.Context, client *http.Client) (Session, error) {
+	var user githubUser
+	if err := getGitHubJSON(ctx, client, "https://api.github.com/user", &user); err != nil {
+		return Session{}, fmt.Errorf("fetching user: %w", err)
+	}
+
+	email := user.Email
+	if email == "" {
+		var emails []githubEmail
+		if err := getGitHubJSON(ctx, client, "https://api.github.com/user/emails", &emails); err != nil {
+			return Session{}, fmt.Errorf("fetching email: %w", err)
+		}
+		for _, e := range emails {
+			if e.Primary && e.Verified {
+				email = e.Email
+				break
+			}
+		}
+	}
+
+	groups, err := fetchGitHubOrgs(ctx, client)
+	if err != nil {
+		return Session{}, fmt.Errorf("fetching orgs: %w", err)
+	}
+
+	return Session{
+		Subject: strconv.FormatInt(user.ID, 10),
+		Email:   email,
+		Groups:  groups,
+	}, nil
+}
+
+// fetchGitHubOrgs retrieves the full list of the authenticated user's
+// organization logins, following pagination since GitHub caps "GET
+// /user/orgs" at 30 entries per page by default — without it, a user in
+// more than one page of orgs could silently lose CanEdit membership in
+// whichever org ADMIN_EDIT_GROUP names.
+func fetchGitHubOrgs(ctx context.Context, client *http.Client) ([]string, error) {
+	const perPage = 100
+
+	var logins []string
+	for page := 1; ; page++ {
+		url := fmt.Sprintf("https://api.github.com/user/orgs?per_page=%d&page=%d", perPage, page)
+		var orgs []githubOrg
+		if err := getGitHubJSON(ctx, client, url, &orgs); err != nil {
+			return nil, err
+		}
+		for _, org := range orgs {
+			logins = append(logins, org.Login)
+		}
+		if len(orgs) < perPage {
+			return logins, nil
+		}
+	}
+}
+
+// getGitHubJSON GETs url with client (an OAuth2 token-bearing client) and
+// decodes the JSON response body into v.
+func getGitHubJSON(ctx context.Context, client *http.Client, url string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: unexpected status %d", url, resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+func (a *Authenticator) handleLogout(w http.ResponseWriter, r *http.Request) {
+	http.SetCookie(w, &http.Cookie{Name: sessionCookieName, Path: "/", MaxAge: -1})
+	http.Redirect(w, r, "/", http.StatusFound)
+}
+
+// --- session cookie ---------------------------------------------------
+
+const sessionCookieName = "ssl_monitor_session"
+
+func (a *Authenticator) setSessionCookie(w http.ResponseWriter, r *http.Request, s Session) error {
+	signed, err := signValue(a.cfg.SessionKey, s, sessionTTL)
+	if err != nil {
+		return err
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookieName,
+		Value:    signed,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   !a.cfg.CookieInsecure,
+		SameSite: http.SameSiteLaxMode,
+		MaxAge:   int(sessionTTL.Seconds()),
+	})
+	return nil
+}
+
+func (a *Authenticator) sessionFromRequest(r *http.Request) (Session, error) {
+	cookie, err := r.Cookie(sessionCookieName)
+	if err != nil {
+		return Session{}, err
+	}
+	var s Session
+	if err := verifyValue(a.cfg.SessionKey, cookie.Value, &s); err != nil {
+		return Session{}, err
+	}
+	return s, nil
+}
+
+// requireSession rejects requests with no valid session, redirecting
+// browsers to the login flow.
+func (a *Authenticator) requireSession(next func(http.ResponseWriter, *http.Request, Session)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		session, err := a.sessionFromRequest(r)
+		if err != nil {
+			http.Redirect(w, r, "/login", http.StatusFound)
+			return
+		}
+		next(w, r, session)
+	}
+}
+
+// requireEdit additionally rejects authenticated sessions that aren't in
+// the configured edit group.
+func (a *Authenticator) requireEdit(next func(http.ResponseWriter, *http.Request, Session)) http.HandlerFunc {
+	return a.requireSession(func(w http.ResponseWriter, r *http.Request, session Session) {
+		if !session.CanEdit(a.cfg.EditGroup) {
+			http.Error(w, "forbidden: missing edit group membership", http.StatusForbidden)
+			return
+		}
+		next(w, r, session)
+	})
+}
+
+// --- signed cookie values -----------------------------------------------
+
+// signedEnvelope wraps a JSON payload with an expiry, so every signed
+// cookie value (login PKCE state, session) carries and enforces its own
+// TTL independent of the cookie's own Max-Age.
+type signedEnvelope struct {
+	Payload   json.RawMessage `json:"payload"`
+	ExpiresAt time.Time       `json:"exp"`
+}
+
+// signValue JSON-encodes v and returns a base64 "envelope.signature" token
+// HMAC-signed with key, valid until ttl elapses. It's used for both the
+// login PKCE cookie and the session cookie so neither can be forged or
+// replayed past its expiry without the server's SESSION_SECRET.
+func signValue(key []byte, v interface{}, ttl time.Duration) (string, error) {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+
+	env, err := json.Marshal(signedEnvelope{Payload: payload, ExpiresAt: time.Now().Add(ttl)})
+	if err != nil {
+		return "", err
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(env)
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(encoded))
+	sig := base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+
+	return encoded + "." + sig, nil
+}
+
+func verifyValue(key []byte, token string, v interface{}) error {
+	parts := strings.SplitN(token, ".", 2)
+	if len(parts) != 2 {
+		return fmt.Errorf("malformed token")
+	}
+	encoded, sig := parts[0], parts[1]
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(encoded))
+	expected := base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+	if subtle.ConstantTimeCompare([]byte(sig), []byte(expected)) != 1 {
+		return fmt.Errorf("invalid signature")
+	}
+
+	envBytes, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return err
+	}
+
+	var env signedEnvelope
+	if err := json.Unmarshal(envBytes, &env); err != nil {
+		return err
+	}
+	if time.Now().After(env.ExpiresAt) {
+		return fmt.Errorf("token expired")
+	}
+
+	return json.Unmarshal(env.Payload, v)
+}
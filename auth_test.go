@@ -0,0 +1,69 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSignValueVerifyValueRoundTrip(t *testing.T) {
+	key := []byte("test-session-secret")
+	in := pkceState{State: "state123", Verifier: "verifier456"}
+
+	token, err := signValue(key, in, time.Hour)
+	if err != nil {
+		t.Fatalf("signValue() error = %v", err)
+	}
+
+	var out pkceState
+	if err := verifyValue(key, token, &out); err != nil {
+		t.Fatalf("verifyValue() error = %v", err)
+	}
+	if out != in {
+		t.Errorf("verifyValue() = %+v, want %+v", out, in)
+	}
+}
+
+func TestVerifyValueRejectsTamperedToken(t *testing.T) {
+	key := []byte("test-session-secret")
+	token, err := signValue(key, pkceState{State: "state123", Verifier: "verifier456"}, time.Hour)
+	if err != nil {
+		t.Fatalf("signValue() error = %v", err)
+	}
+
+	parts := strings.SplitN(token, ".", 2)
+	if len(parts) != 2 {
+		t.Fatalf("signValue() token has no signature separator: %q", token)
+	}
+	tampered := parts[0] + "x." + parts[1]
+
+	var out pkceState
+	if err := verifyValue(key, tampered, &out); err == nil {
+		t.Error("verifyValue() on a tampered payload returned nil error, want an error")
+	}
+}
+
+func TestVerifyValueRejectsWrongKey(t *testing.T) {
+	token, err := signValue([]byte("key-one"), pkceState{State: "state123"}, time.Hour)
+	if err != nil {
+		t.Fatalf("signValue() error = %v", err)
+	}
+
+	var out pkceState
+	if err := verifyValue([]byte("key-two"), token, &out); err == nil {
+		t.Error("verifyValue() with a different key returned nil error, want an error")
+	}
+}
+
+func TestVerifyValueRejectsExpiredToken(t *testing.T) {
+	key := []byte("test-session-secret")
+	token, err := signValue(key, pkceState{State: "state123"}, -time.Minute)
+	if err != nil {
+		t.Fatalf("signValue() error = %v", err)
+	}
+
+	var out pkceState
+	if err := verifyValue(key, token, &out); err == nil {
+		t.Error("verifyValue() on an expired token returned nil error, want an error")
+	}
+}
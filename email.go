@@ -0,0 +1,523 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"time"
+)
+
+func buildEmailHTMLBody(allCerts []CertInfo, expiringCerts []CertInfo, embedImages bool) string {
+	body := `<!DOCTYPE html>
+<html>
+<head>
+	<meta charset="UTF-8">
+	<meta name="viewport" content="width=device-width, initial-scale=1.0">
+	<style>
+		* {
+			margin: 0;
+			padding: 0;
+			box-sizing: border-box;
+		}
+		body {
+			font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, 'Helvetica Neue', Arial, sans-serif;
+			background: linear-gradient(135deg, #667eea 0%, #764ba2 100%);
+			padding: 20px;
+			line-height: 1.6;
+		}
+		.container {
+			max-width: 900px;
+			margin: 0 auto;
+			background: #ffffff;
+			border-radius: 16px;
+			box-shadow: 0 20px 60px rgba(0, 0, 0, 0.3);
+			overflow: hidden;
+		}
+		.header {
+			background: linear-gradient(135deg, #667eea 0%, #764ba2 100%);
+			color: white;
+			padding: 40px;
+			text-align: center;
+		}
+		.header .logo {
+			display: block;
+			margin: 0 auto 16px;
+			border-radius: 12px;
+		}
+		.header h1 {
+			font-size: 32px;
+			font-weight: 700;
+			margin-bottom: 10px;
+			text-shadow: 0 2px 4px rgba(0, 0, 0, 0.2);
+		}
+		.header p {
+			font-size: 16px;
+			opacity: 0.9;
+		}
+		.content {
+			padding: 40px;
+		}
+		.alert-section {
+			background: linear-gradient(135deg, #ff6b6b 0%, #ee5a6f 100%);
+			color: white;
+			padding: 30px;
+			border-radius: 12px;
+			margin-bottom: 30px;
+			box-shadow: 0 4px 12px rgba(255, 107, 107, 0.3);
+		}
+		.alert-section h2 {
+			font-size: 24px;
+			margin-bottom: 20px;
+			display: flex;
+			align-items: center;
+			gap: 10px;
+		}
+		.section-title {
+			font-size: 24px;
+			color: #333;
+			margin-bottom: 20px;
+			padding-bottom: 10px;
+			border-bottom: 3px solid #667eea;
+			font-weight: 600;
+		}
+		.cert-card {
+			background: #f8f9fa;
+			border-left: 4px solid #667eea;
+			padding: 20px;
+			margin-bottom: 15px;
+			border-radius: 8px;
+			transition: transform 0.2s, box-shadow 0.2s;
+		}
+		.cert-card:hover {
+			transform: translateX(5px);
+			box-shadow: 0 4px 12px rgba(0, 0, 0, 0.1);
+		}
+		.cert-card.critical {
+			border-left-color: #dc3545;
+			background: #fff5f5;
+		}
+		.cert-card.warning {
+			border-left-color: #ffc107;
+			background: #fffbf0;
+		}
+		.cert-card.caution {
+			border-left-color: #ff9800;
+			background: #fff8f0;
+		}
+		.cert-header {
+			display: flex;
+			justify-content: space-between;
+			align-items: center;
+			margin-bottom: 12px;
+			flex-wrap: wrap;
+			gap: 10px;
+		}
+		.cert-name {
+			font-size: 18px;
+			font-weight: 700;
+			color: #333;
+		}
+		.cert-badge {
+			display: inline-block;
+			padding: 6px 16px;
+			border-radius: 20px;
+			font-size: 14px;
+			font-weight: 600;
+			color: white;
+		}
+		.badge-icon {
+			vertical-align: middle;
+			margin-right: 6px;
+		}
+		.badge-critical {
+			background: linear-gradient(135deg, #dc3545 0%, #c82333 100%);
+			animation: pulse 2s infinite;
+		}
+		.badge-warning {
+			background: linear-gradient(135deg, #ffc107 0%, #e0a800 100%);
+		}
+		.badge-caution {
+			background: linear-gradient(135deg, #ff9800 0%, #e68900 100%);
+		}
+		.badge-ok {
+			background: linear-gradient(135deg, #28a745 0%, #218838 100%);
+		}
+		.status-badge {
+			margin-left: 8px;
+		}
+		.badge-self-signed {
+			background: linear-gradient(135deg, #6c757d 0%, #495057 100%);
+		}
+		@keyframes pulse {
+			0%, 100% { opacity: 1; }
+			50% { opacity: 0.7; }
+		}
+		.cert-details {
+			display: grid;
+			grid-template-columns: repeat(auto-fit, minmax(250px, 1fr));
+			gap: 12px;
+			color: #555;
+			font-size: 14px;
+		}
+		.cert-detail-item {
+			display: flex;
+			gap: 8px;
+		}
+		.cert-detail-label {
+			font-weight: 600;
+			color: #333;
+		}
+		.cert-url {
+			color: #667eea;
+			text-decoration: none;
+			word-break: break-all;
+		}
+		.stats-container {
+			display: grid;
+			grid-template-columns: repeat(auto-fit, minmax(200px, 1fr));
+			gap: 20px;
+			margin-bottom: 30px;
+		}
+		.stat-card {
+			background: linear-gradient(135deg, #667eea 0%, #764ba2 100%);
+			color: white;
+			padding: 25px;
+			border-radius: 12px;
+			text-align: center;
+			box-shadow: 0 4px 12px rgba(102, 126, 234, 0.3);
+		}
+		.stat-number {
+			font-size: 36px;
+			font-weight: 700;
+			margin-bottom: 8px;
+		}
+		.stat-label {
+			font-size: 14px;
+			opacity: 0.9;
+			text-transform: uppercase;
+			letter-spacing: 1px;
+		}
+		.footer {
+			background: #f8f9fa;
+			padding: 30px;
+			text-align: center;
+			color: #666;
+			font-size: 14px;
+			border-top: 1px solid #e0e0e0;
+		}
+		.footer a {
+			color: #667eea;
+			text-decoration: none;
+			font-weight: 600;
+		}
+		@media (max-width: 600px) {
+			.header h1 {
+				font-size: 24px;
+			}
+			.content {
+				padding: 20px;
+			}
+			.cert-header {
+				flex-direction: column;
+				align-items: flex-start;
+			}
+		}
+	</style>
+</head>
+<body>
+	<div class="container">
+		<div class="header">
+			<img src="` + assetSrc("logo.png", "logo", embedImages) + `" alt="SSL Certificate Monitor" class="logo" width="56" height="56" />
+			<h1>üîí SSL Certificate Monitor</h1>
+			<p>Generated: ` + time.Now().Format("Monday, January 2, 2006 at 3:04 PM") + `</p>
+		</div>
+		<div class="content">`
+
+	// Statistics
+	criticalCount := 0
+	warningCount := 0
+	cautionCount := 0
+	okCount := 0
+
+	for _, cert := range allCerts {
+		if cert.DaysRemaining <= 7 {
+			criticalCount++
+		} else if cert.DaysRemaining <= 14 {
+			warningCount++
+		} else if cert.DaysRemaining <= 30 {
+			cautionCount++
+		} else {
+			okCount++
+		}
+	}
+
+	body += `<div class="stats-container">`
+	body += fmt.Sprintf(`
+		<div class="stat-card">
+			<div class="stat-number">%d</div>
+			<div class="stat-label">Total Certificates</div>
+		</div>`, len(allCerts))
+	
+	if criticalCount > 0 {
+		body += fmt.Sprintf(`
+		<div class="stat-card" style="background: linear-gradient(135deg, #dc3545 0%%, #c82333 100%%);">
+			<div class="stat-number">%d</div>
+			<div class="stat-label">Critical (‚â§7 days)</div>
+		</div>`, criticalCount)
+	}
+
+	if warningCount > 0 {
+		body += fmt.Sprintf(`
+		<div class="stat-card" style="background: linear-gradient(135deg, #ffc107 0%%, #e0a800 100%%);">
+			<div class="stat-number">%d</div>
+			<div class="stat-label">Warning (8-14 days)</div>
+		</div>`, warningCount)
+	}
+	
+	body += `</div>`
+
+	// Expiring certificates section
+	if len(expiringCerts) > 0 {
+		body += `<div class="alert-section">
+			<h2><span style="font-size: 28px;">‚ö†Ô∏è</span> Certificates Expiring Soon</h2>
+			<p style="margin-bottom: 20px; opacity: 0.9;">The following certificates need immediate attention:</p>`
+
+		for _, cert := range expiringCerts {
+			badgeClass := "badge-warning"
+			cardClass := "warning"
+			if cert.DaysRemaining <= 7 {
+				badgeClass = "badge-critical"
+				cardClass = "critical"
+			}
+
+			body += fmt.Sprintf(`
+			<div class="cert-card %s" style="background: white; border-left-color: white;">
+				<div class="cert-header">
+					<div class="cert-name" style="color: #333;">%s</div>
+					<span class="cert-badge %s">%s%d days remaining</span>%s
+				</div>
+				<div class="cert-details">
+					<div class="cert-detail-item">
+						<span class="cert-detail-label">URL:</span>
+						<span>%s</span>
+					</div>
+					<div class="cert-detail-item">
+						<span class="cert-detail-label">Certificate:</span>
+						<span>%s</span>
+					</div>
+					<div class="cert-detail-item">
+						<span class="cert-detail-label">Expires:</span>
+						<span>%s</span>
+					</div>
+					<div class="cert-detail-item">
+						<span class="cert-detail-label">Serial:</span>
+						<span>%s</span>
+					</div>
+					<div class="cert-detail-item">
+						<span class="cert-detail-label">SANs:</span>
+						<span>%s</span>
+					</div>
+				</div>
+			</div>`,
+				cardClass, html.EscapeString(cert.Name), badgeClass, dayBadgeIconHTML(badgeClass, embedImages), cert.DaysRemaining, statusBadgeHTML(cert.Status),
+				html.EscapeString(cert.URL), html.EscapeString(cert.CommonName), cert.ExpiryDate.Format("January 2, 2006"),
+				html.EscapeString(cert.SerialNumber), html.EscapeString(formatSANs(cert.SANs)))
+		}
+
+		body += `</div>`
+	}
+
+	// All certificates section
+	body += `<h2 class="section-title">üìã All Monitored Certificates</h2>`
+
+	for _, cert := range allCerts {
+		badgeClass := "badge-ok"
+		cardClass := ""
+		badgeText := fmt.Sprintf("%d days", cert.DaysRemaining)
+
+		if cert.DaysRemaining <= 7 {
+			badgeClass = "badge-critical"
+			cardClass = "critical"
+		} else if cert.DaysRemaining <= 14 {
+			badgeClass = "badge-warning"
+			cardClass = "warning"
+		} else if cert.DaysRemaining <= 30 {
+			badgeClass = "badge-caution"
+			cardClass = "caution"
+		}
+
+		body += fmt.Sprintf(`
+		<div class="cert-card %s">
+			<div class="cert-header">
+				<div class="cert-name">%s</div>
+				<span class="cert-badge %s">%s%s</span>%s
+			</div>
+			<div class="cert-details">
+				<div class="cert-detail-item">
+					<span class="cert-detail-label">üåê URL:</span>
+					<span class="cert-url">%s</span>
+				</div>
+				<div class="cert-detail-item">
+					<span class="cert-detail-label">üìú Certificate:</span>
+					<span>%s</span>
+				</div>
+				<div class="cert-detail-item">
+					<span class="cert-detail-label">üìÖ Expires:</span>
+					<span>%s</span>
+				</div>
+				<div class="cert-detail-item">
+					<span class="cert-detail-label">🏢 Issuer:</span>
+					<span>%s</span>
+				</div>
+				<div class="cert-detail-item">
+					<span class="cert-detail-label">🔑 Key:</span>
+					<span>%s</span>
+				</div>
+				<div class="cert-detail-item">
+					<span class="cert-detail-label">🔢 Serial:</span>
+					<span>%s</span>
+				</div>
+				<div class="cert-detail-item">
+					<span class="cert-detail-label">🧾 SANs:</span>
+					<span>%s</span>
+				</div>
+				<div class="cert-detail-item">
+					<span class="cert-detail-label">⛓ Chain:</span>
+					<span>%s</span>
+				</div>
+				<div class="cert-detail-item">
+					<span class="cert-detail-label">🚫 Revocation:</span>
+					<span>%s</span>
+				</div>
+			</div>
+		</div>`,
+			cardClass, html.EscapeString(cert.Name), badgeClass, dayBadgeIconHTML(badgeClass, embedImages), badgeText, statusBadgeHTML(cert.Status),
+			html.EscapeString(cert.URL), html.EscapeString(cert.CommonName), cert.ExpiryDate.Format("January 2, 2006"),
+			html.EscapeString(cert.Issuer), html.EscapeString(describeKeyLabel(cert)),
+			html.EscapeString(cert.SerialNumber), html.EscapeString(formatSANs(cert.SANs)), formatChainSummary(cert.Chain),
+			html.EscapeString(formatRevocationEndpoints(cert.OCSPServers, cert.CRLDistributionPoints)))
+	}
+
+	body += `
+		</div>
+		<div class="footer">
+			<p>Automated SSL Certificate Monitoring System</p>
+			<p style="margin-top: 10px; font-size: 12px;">This is an automated notification. Please do not reply to this email.</p>
+		</div>
+	</div>
+</body>
+</html>`
+
+	return body
+}
+
+// formatSANs joins a certificate's Subject Alternative Names for display,
+// falling back to a placeholder for legacy certs that rely solely on the CN.
+func formatSANs(sans []string) string {
+	if len(sans) == 0 {
+		return "(none)"
+	}
+	return strings.Join(sans, ", ")
+}
+
+// formatChainSummary renders a one-line count of the intermediate
+// certificates returned alongside the leaf, so operators can spot a missing
+// intermediate without reading the raw chain.
+func formatChainSummary(chain []ChainCertInfo) string {
+	if len(chain) == 0 {
+		return "leaf only, no intermediates"
+	}
+	return fmt.Sprintf("%d intermediate(s)", len(chain))
+}
+
+// formatRevocationEndpoints summarizes a leaf's OCSP and CRL endpoints for
+// display, so their presence (or absence) is visible without listing every
+// URL.
+func formatRevocationEndpoints(ocsp, crl []string) string {
+	switch {
+	case len(ocsp) == 0 && len(crl) == 0:
+		return "none"
+	case len(ocsp) > 0 && len(crl) > 0:
+		return fmt.Sprintf("OCSP (%d), CRL (%d)", len(ocsp), len(crl))
+	case len(ocsp) > 0:
+		return fmt.Sprintf("OCSP (%d)", len(ocsp))
+	default:
+		return fmt.Sprintf("CRL (%d)", len(crl))
+	}
+}
+
+// dayBadgeIconHTML renders the <img> icon matching a day-count badge's
+// severity, referencing the bundled icon-critical/warning/caution/ok.png
+// assets via assetSrc so they ship as cid: references (or hosted URLs) the
+// same way the header logo does, rather than being attached but unused.
+func dayBadgeIconHTML(badgeClass string, embedImages bool) string {
+	icon := strings.TrimPrefix(badgeClass, "badge-")
+	filename := "icon-" + icon + ".png"
+	return fmt.Sprintf(`<img src="%s" alt="" class="badge-icon" width="14" height="14">`, assetSrc(filename, icon, embedImages))
+}
+
+// statusBadgeHTML renders a CertInfo's Status as a small badge appended
+// next to the day-count badge, empty for CertStatusOK (and the zero value,
+// for certs scanned before Status existed) since a healthy chain needs no
+// extra callout.
+func statusBadgeHTML(status CertStatus) string {
+	if status == "" || status == CertStatusOK {
+		return ""
+	}
+	return fmt.Sprintf(`<span class="cert-badge status-badge %s">%s</span>`, statusBadgeClass(status), describeCertStatus(status))
+}
+
+// statusBadgeClass maps a CertStatus to the CSS class controlling its badge
+// color, reusing the existing critical/caution palette so status badges
+// read consistently with the day-count badges next to them.
+func statusBadgeClass(status CertStatus) string {
+	switch status {
+	case CertStatusExpiredLeaf, CertStatusExpiredIntermediate:
+		return "badge-critical"
+	case CertStatusUnknownIssuer, CertStatusHostnameMismatch:
+		return "badge-caution"
+	case CertStatusSelfSigned:
+		return "badge-self-signed"
+	default:
+		return ""
+	}
+}
+
+// buildEmailTextBody renders a plain-text rendition of the report for the
+// multipart/alternative text part, for mail clients that don't show HTML.
+func buildEmailTextBody(allCerts []CertInfo, expiringCerts []CertInfo) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "SSL Certificate Monitoring Report\nGenerated: %s\n\n", time.Now().Format("Monday, January 2, 2006 at 3:04 PM"))
+
+	if len(expiringCerts) > 0 {
+		fmt.Fprintf(&b, "CERTIFICATES EXPIRING SOON\n%s\n", strings.Repeat("-", 26))
+		for _, cert := range expiringCerts {
+			fmt.Fprintf(&b, "- %s (%s): %d days remaining, expires %s%s\n",
+				cert.Name, cert.URL, cert.DaysRemaining, cert.ExpiryDate.Format("January 2, 2006"), statusSuffix(cert.Status))
+		}
+		b.WriteString("\n")
+	}
+
+	fmt.Fprintf(&b, "ALL MONITORED CERTIFICATES\n%s\n", strings.Repeat("-", 26))
+	for _, cert := range allCerts {
+		fmt.Fprintf(&b, "- %s (%s): %s, issued by %s, expires %s (%d days)%s\n",
+			cert.Name, cert.URL, describeKeyLabel(cert), cert.Issuer,
+			cert.ExpiryDate.Format("January 2, 2006"), cert.DaysRemaining, statusSuffix(cert.Status))
+		fmt.Fprintf(&b, "    Serial: %s | SANs: %s | Chain: %s | Revocation: %s\n",
+			cert.SerialNumber, formatSANs(cert.SANs), formatChainSummary(cert.Chain),
+			formatRevocationEndpoints(cert.OCSPServers, cert.CRLDistributionPoints))
+	}
+
+	return b.String()
+}
+
+// statusSuffix renders a CertInfo's Status as a trailing " [Label]" marker
+// for the plain-text report, empty for CertStatusOK so healthy certs read
+// the same as before Status existed.
+func statusSuffix(status CertStatus) string {
+	if status == "" || status == CertStatusOK {
+		return ""
+	}
+	return fmt.Sprintf(" [%s]", describeCertStatus(status))
+}
@@ -1,15 +1,17 @@
 package main
 
 import (
-	"bytes"
-	"crypto/tls"
-	"encoding/json"
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
 	"fmt"
+	"io"
 	"log"
-	"net/http"
-	"net/smtp"
 	"os"
 	"strings"
+	"text/tabwriter"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -17,11 +19,63 @@ import (
 )
 
 type CertInfo struct {
-	URL          string
-	Name         string
-	ExpiryDate   time.Time
-	DaysRemaining int
-	CommonName   string
+	URL                   string
+	Name                  string
+	ExpiryDate            time.Time
+	DaysRemaining         int
+	CommonName            string
+	Issuer                string
+	SANs                  []string
+	SignatureAlgorithm    string
+	KeyType               string
+	KeySize               int
+	SerialNumber          string
+	OCSPServers           []string
+	CRLDistributionPoints []string
+	Chain                 []ChainCertInfo
+	Status                CertStatus
+}
+
+// CertStatus classifies the outcome of validating a peer's certificate
+// chain, distinguishing failure modes that used to collapse into a single
+// "tls: failed to verify certificate" error from tls.Dial's built-in
+// verification.
+type CertStatus string
+
+const (
+	CertStatusOK                  CertStatus = "ok"
+	CertStatusExpiredLeaf         CertStatus = "expired_leaf"
+	CertStatusExpiredIntermediate CertStatus = "expired_intermediate"
+	CertStatusUnknownIssuer       CertStatus = "unknown_issuer"
+	CertStatusHostnameMismatch    CertStatus = "hostname_mismatch"
+	CertStatusSelfSigned          CertStatus = "self_signed"
+)
+
+// describeCertStatus renders a CertStatus as the short label shown next to
+// the day-count badge in email/Slack output.
+func describeCertStatus(status CertStatus) string {
+	switch status {
+	case CertStatusExpiredLeaf:
+		return "Expired"
+	case CertStatusExpiredIntermediate:
+		return "Expired Intermediate"
+	case CertStatusUnknownIssuer:
+		return "Unknown Issuer"
+	case CertStatusHostnameMismatch:
+		return "Hostname Mismatch"
+	case CertStatusSelfSigned:
+		return "Self-Signed"
+	default:
+		return "OK"
+	}
+}
+
+// ChainCertInfo summarizes one certificate in the peer's chain beyond the leaf.
+type ChainCertInfo struct {
+	CommonName string
+	Issuer     string
+	NotAfter   time.Time
+	IsCA       bool
 }
 
 var (
@@ -42,12 +96,25 @@ func main() {
 		logger.Fatal("Error loading .env file")
 	}
 
-	// Run immediately on start
-	checkCertificates()
+	if ms := newMetricsServerFromEnv(); ms != nil {
+		ms.Start()
+	}
+
+	if as, err := newAdminServerFromEnv(context.Background()); err != nil {
+		logger.Fatalf("Error starting admin UI: %v", err)
+	} else if as != nil {
+		as.Start()
+	}
+
+	// Run immediately on start and print a summary of what we found before
+	// the first scheduled scan, so operators don't have to wait to sanity
+	// check the monitor's view of each host.
+	allCerts := checkCertificates()
+	PrintCertsInfo(allCerts, os.Stdout)
 
 	// Setup cron job to run daily at 12:00 AM
 	c := cron.New()
-	_, err = c.AddFunc("0 0 * * *", checkCertificates)
+	_, err = c.AddFunc("0 0 * * *", func() { checkCertificates() })
 	if err != nil {
 		logger.Fatalf("Error setting up cron job: %v", err)
 	}
@@ -68,48 +135,86 @@ func initLogger() {
 	logger = log.New(logFile, "", log.LstdFlags)
 }
 
-func checkCertificates() {
+func checkCertificates() []CertInfo {
 	logger.Println("Starting certificate check...")
 
-	urls := getURLsFromEnv()
-	if len(urls) == 0 {
-		logger.Println("No URLs found in environment variables")
-		return
+	targets, err := loadTargets()
+	if err != nil {
+		logger.Printf("Error loading monitored endpoints: %v", err)
+		return nil
+	}
+	if len(targets) == 0 {
+		logger.Println("No URLs configured to monitor")
+		return nil
 	}
 
+	results := runScans(context.Background(), targets, scanConfigFromEnv())
+
 	var expiringCerts []CertInfo
 	var allCerts []CertInfo
 
-	for name, url := range urls {
-		certInfo, err := getCertificateInfo(url, name)
-		if err != nil {
-			logger.Printf("Error checking %s (%s): %v", name, url, err)
+	for _, result := range results {
+		if result.Err != nil {
+			logger.Printf("Error checking %s (%s): %v", result.Name, result.URL, result.Err)
 			continue
 		}
 
-		allCerts = append(allCerts, certInfo)
-		logger.Printf("%s (%s): Expires in %d days", name, url, certInfo.DaysRemaining)
+		statusCache.Store(result.Name, result.Cert)
+		allCerts = append(allCerts, result.Cert)
+		logger.Printf("%s (%s): Expires in %d days", result.Name, result.URL, result.Cert.DaysRemaining)
 
-		if certInfo.DaysRemaining <= 14 {
-			expiringCerts = append(expiringCerts, certInfo)
+		if result.Cert.DaysRemaining <= 14 {
+			expiringCerts = append(expiringCerts, result.Cert)
 		}
 	}
 
-	// Send email with all certificates
-	if len(allCerts) > 0 {
-		sendEmail(allCerts, expiringCerts)
+	recordMetrics(results)
+
+	report := Report{
+		AllCerts:      allCerts,
+		ExpiringCerts: expiringCerts,
+		GeneratedAt:   time.Now(),
 	}
 
-	// Send Slack notification only for expiring certificates
-	if len(expiringCerts) > 0 {
-		sendSlackNotification(expiringCerts)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	for _, notifier := range loadNotifiers() {
+		if err := notifier.Notify(ctx, report); err != nil {
+			logger.Printf("Error from notifier %T: %v", notifier, err)
+		}
 	}
 
 	logger.Println("Certificate check completed")
+
+	return allCerts
+}
+
+// loadTargets returns the endpoints to scan: the admin UI's SQLite store
+// when it's enabled, or the legacy URL_* environment variables otherwise.
+func loadTargets() ([]ScanTarget, error) {
+	if adminStore != nil {
+		return loadTargetsFromStore(context.Background(), adminStore)
+	}
+	return getTargetsFromEnv(), nil
 }
 
-func getURLsFromEnv() map[string]string {
-	urls := make(map[string]string)
+// getTargetsFromEnv builds scan targets from the legacy URL_* environment
+// variables, the default path when ADMIN_ADDR/DB_PATH aren't set. Each
+// endpoint may be further tuned with sibling variables keyed by the same
+// NAME suffix as its URL_NAME entry:
+//
+//	PROTOCOL_NAME          - "smtp", "smtp-submission", "imap", "pop3", "ldap", "postgres" (default: https)
+//	SNI_NAME                - ServerName override, for virtual-hosted TLS or probing an IP
+//	CLIENT_CERT_NAME/CLIENT_KEY_NAME - client cert/key paths for mTLS-protected endpoints
+//	MIN_TLS_VERSION_NAME    - "1.0", "1.1", "1.2", "1.3"
+//	CIPHER_SUITES_NAME      - comma-separated cipher suite names, for auditing legacy TLS
+//
+// An endpoint with an invalid MIN_TLS_VERSION_NAME/CIPHER_SUITES_NAME
+// override is logged and skipped rather than failing the whole scan,
+// mirroring loadTargetsFromStore's handling of the same fields.
+func getTargetsFromEnv() []ScanTarget {
+	var targets []ScanTarget
 
 	// Load URLs from environment variables
 	// Format: URL_NAME_1=https://example.com, URL_NAME_2=https://example2.com
@@ -117,490 +222,116 @@ func getURLsFromEnv() map[string]string {
 		pair := strings.SplitN(env, "=", 2)
 		if len(pair) == 2 && strings.HasPrefix(pair[0], "URL_") {
 			name := strings.TrimPrefix(pair[0], "URL_")
-			urls[name] = pair[1]
-		}
-	}
-
-	return urls
-}
+			target := ScanTarget{
+				Name:           name,
+				URL:            pair[1],
+				Protocol:       os.Getenv("PROTOCOL_" + name),
+				ServerName:     os.Getenv("SNI_" + name),
+				ClientCertFile: os.Getenv("CLIENT_CERT_" + name),
+				ClientKeyFile:  os.Getenv("CLIENT_KEY_" + name),
+			}
 
-func getCertificateInfo(url, name string) (CertInfo, error) {
-	// Remove protocol if present
-	url = strings.TrimPrefix(url, "https://")
-	url = strings.TrimPrefix(url, "http://")
+			minVersion, err := parseMinTLSVersion(os.Getenv("MIN_TLS_VERSION_" + name))
+			if err != nil {
+				logger.Printf("Skipping %s (%s): %v", name, pair[1], err)
+				continue
+			}
+			target.MinVersion = minVersion
 
-	// Add port if not present
-	if !strings.Contains(url, ":") {
-		url += ":443"
-	}
+			cipherSuites, err := parseCipherSuites(os.Getenv("CIPHER_SUITES_" + name))
+			if err != nil {
+				logger.Printf("Skipping %s (%s): %v", name, pair[1], err)
+				continue
+			}
+			target.CipherSuites = cipherSuites
 
-	conn, err := tls.Dial("tcp", url, &tls.Config{
-		InsecureSkipVerify: false,
-	})
-	if err != nil {
-		return CertInfo{}, err
+			targets = append(targets, target)
+		}
 	}
-	defer conn.Close()
 
-	certs := conn.ConnectionState().PeerCertificates
-	if len(certs) == 0 {
-		return CertInfo{}, fmt.Errorf("no certificates found")
-	}
+	return targets
+}
 
+// parseCertInfo builds a CertInfo from the leaf certificate and chain
+// returned by a successful dial of host on behalf of name.
+func parseCertInfo(certs []*x509.Certificate, host, name string) CertInfo {
 	cert := certs[0]
 	expiryDate := cert.NotAfter
 	daysRemaining := int(time.Until(expiryDate).Hours() / 24)
 
-	return CertInfo{
-		URL:          url,
-		Name:         name,
-		ExpiryDate:   expiryDate,
-		DaysRemaining: daysRemaining,
-		CommonName:   cert.Subject.CommonName,
-	}, nil
-}
-
-func sendEmail(allCerts []CertInfo, expiringCerts []CertInfo) {
-	smtpHost := os.Getenv("SMTP_HOST")
-	smtpPort := os.Getenv("SMTP_PORT")
-	smtpUser := os.Getenv("SMTP_USER")
-	smtpPass := os.Getenv("SMTP_PASS")
-	emailFrom := os.Getenv("EMAIL_FROM")
-	emailTo := os.Getenv("EMAIL_TO")
+	keyType, keySize := describePublicKey(cert.PublicKey)
 
-	if smtpHost == "" || smtpPort == "" || emailFrom == "" || emailTo == "" {
-		logger.Println("Email configuration missing, skipping email notification")
-		return
+	var chain []ChainCertInfo
+	for _, c := range certs[1:] {
+		chain = append(chain, ChainCertInfo{
+			CommonName: c.Subject.CommonName,
+			Issuer:     c.Issuer.CommonName,
+			NotAfter:   c.NotAfter,
+			IsCA:       c.IsCA,
+		})
 	}
 
-	subject := "SSL Certificate Monitoring Report"
-	body := buildEmailBody(allCerts, expiringCerts)
-
-	msg := []byte(fmt.Sprintf("From: %s\r\n"+
-		"To: %s\r\n"+
-		"Subject: %s\r\n"+
-		"MIME-Version: 1.0\r\n"+
-		"Content-Type: text/html; charset=UTF-8\r\n"+
-		"\r\n"+
-		"%s\r\n", emailFrom, emailTo, subject, body))
-
-	auth := smtp.PlainAuth("", smtpUser, smtpPass, smtpHost)
-	err := smtp.SendMail(smtpHost+":"+smtpPort, auth, emailFrom, []string{emailTo}, msg)
-	if err != nil {
-		logger.Printf("Error sending email: %v", err)
-		return
+	return CertInfo{
+		URL:                   host,
+		Name:                  name,
+		ExpiryDate:            expiryDate,
+		DaysRemaining:         daysRemaining,
+		CommonName:            cert.Subject.CommonName,
+		Issuer:                cert.Issuer.CommonName,
+		SANs:                  cert.DNSNames,
+		SignatureAlgorithm:    cert.SignatureAlgorithm.String(),
+		KeyType:               keyType,
+		KeySize:               keySize,
+		SerialNumber:          cert.SerialNumber.String(),
+		OCSPServers:           cert.OCSPServer,
+		CRLDistributionPoints: cert.CRLDistributionPoints,
+		Chain:                 chain,
 	}
-
-	logger.Println("Email sent successfully")
 }
 
-func buildEmailBody(allCerts []CertInfo, expiringCerts []CertInfo) string {
-	body := `<!DOCTYPE html>
-<html>
-<head>
-	<meta charset="UTF-8">
-	<meta name="viewport" content="width=device-width, initial-scale=1.0">
-	<style>
-		* {
-			margin: 0;
-			padding: 0;
-			box-sizing: border-box;
-		}
-		body {
-			font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, 'Helvetica Neue', Arial, sans-serif;
-			background: linear-gradient(135deg, #667eea 0%, #764ba2 100%);
-			padding: 20px;
-			line-height: 1.6;
-		}
-		.container {
-			max-width: 900px;
-			margin: 0 auto;
-			background: #ffffff;
-			border-radius: 16px;
-			box-shadow: 0 20px 60px rgba(0, 0, 0, 0.3);
-			overflow: hidden;
-		}
-		.header {
-			background: linear-gradient(135deg, #667eea 0%, #764ba2 100%);
-			color: white;
-			padding: 40px;
-			text-align: center;
-		}
-		.header h1 {
-			font-size: 32px;
-			font-weight: 700;
-			margin-bottom: 10px;
-			text-shadow: 0 2px 4px rgba(0, 0, 0, 0.2);
-		}
-		.header p {
-			font-size: 16px;
-			opacity: 0.9;
-		}
-		.content {
-			padding: 40px;
-		}
-		.alert-section {
-			background: linear-gradient(135deg, #ff6b6b 0%, #ee5a6f 100%);
-			color: white;
-			padding: 30px;
-			border-radius: 12px;
-			margin-bottom: 30px;
-			box-shadow: 0 4px 12px rgba(255, 107, 107, 0.3);
-		}
-		.alert-section h2 {
-			font-size: 24px;
-			margin-bottom: 20px;
-			display: flex;
-			align-items: center;
-			gap: 10px;
-		}
-		.section-title {
-			font-size: 24px;
-			color: #333;
-			margin-bottom: 20px;
-			padding-bottom: 10px;
-			border-bottom: 3px solid #667eea;
-			font-weight: 600;
-		}
-		.cert-card {
-			background: #f8f9fa;
-			border-left: 4px solid #667eea;
-			padding: 20px;
-			margin-bottom: 15px;
-			border-radius: 8px;
-			transition: transform 0.2s, box-shadow 0.2s;
-		}
-		.cert-card:hover {
-			transform: translateX(5px);
-			box-shadow: 0 4px 12px rgba(0, 0, 0, 0.1);
-		}
-		.cert-card.critical {
-			border-left-color: #dc3545;
-			background: #fff5f5;
-		}
-		.cert-card.warning {
-			border-left-color: #ffc107;
-			background: #fffbf0;
-		}
-		.cert-card.caution {
-			border-left-color: #ff9800;
-			background: #fff8f0;
-		}
-		.cert-header {
-			display: flex;
-			justify-content: space-between;
-			align-items: center;
-			margin-bottom: 12px;
-			flex-wrap: wrap;
-			gap: 10px;
-		}
-		.cert-name {
-			font-size: 18px;
-			font-weight: 700;
-			color: #333;
-		}
-		.cert-badge {
-			display: inline-block;
-			padding: 6px 16px;
-			border-radius: 20px;
-			font-size: 14px;
-			font-weight: 600;
-			color: white;
-		}
-		.badge-critical {
-			background: linear-gradient(135deg, #dc3545 0%, #c82333 100%);
-			animation: pulse 2s infinite;
-		}
-		.badge-warning {
-			background: linear-gradient(135deg, #ffc107 0%, #e0a800 100%);
-		}
-		.badge-caution {
-			background: linear-gradient(135deg, #ff9800 0%, #e68900 100%);
-		}
-		.badge-ok {
-			background: linear-gradient(135deg, #28a745 0%, #218838 100%);
-		}
-		@keyframes pulse {
-			0%, 100% { opacity: 1; }
-			50% { opacity: 0.7; }
-		}
-		.cert-details {
-			display: grid;
-			grid-template-columns: repeat(auto-fit, minmax(250px, 1fr));
-			gap: 12px;
-			color: #555;
-			font-size: 14px;
-		}
-		.cert-detail-item {
-			display: flex;
-			gap: 8px;
-		}
-		.cert-detail-label {
-			font-weight: 600;
-			color: #333;
-		}
-		.cert-url {
-			color: #667eea;
-			text-decoration: none;
-			word-break: break-all;
-		}
-		.stats-container {
-			display: grid;
-			grid-template-columns: repeat(auto-fit, minmax(200px, 1fr));
-			gap: 20px;
-			margin-bottom: 30px;
-		}
-		.stat-card {
-			background: linear-gradient(135deg, #667eea 0%, #764ba2 100%);
-			color: white;
-			padding: 25px;
-			border-radius: 12px;
-			text-align: center;
-			box-shadow: 0 4px 12px rgba(102, 126, 234, 0.3);
-		}
-		.stat-number {
-			font-size: 36px;
-			font-weight: 700;
-			margin-bottom: 8px;
-		}
-		.stat-label {
-			font-size: 14px;
-			opacity: 0.9;
-			text-transform: uppercase;
-			letter-spacing: 1px;
-		}
-		.footer {
-			background: #f8f9fa;
-			padding: 30px;
-			text-align: center;
-			color: #666;
-			font-size: 14px;
-			border-top: 1px solid #e0e0e0;
-		}
-		.footer a {
-			color: #667eea;
-			text-decoration: none;
-			font-weight: 600;
-		}
-		@media (max-width: 600px) {
-			.header h1 {
-				font-size: 24px;
-			}
-			.content {
-				padding: 20px;
-			}
-			.cert-header {
-				flex-direction: column;
-				align-items: flex-start;
-			}
-		}
-	</style>
-</head>
-<body>
-	<div class="container">
-		<div class="header">
-			<h1>üîí SSL Certificate Monitor</h1>
-			<p>Generated: ` + time.Now().Format("Monday, January 2, 2006 at 3:04 PM") + `</p>
-		</div>
-		<div class="content">`
-
-	// Statistics
-	criticalCount := 0
-	warningCount := 0
-	cautionCount := 0
-	okCount := 0
-
-	for _, cert := range allCerts {
-		if cert.DaysRemaining <= 7 {
-			criticalCount++
-		} else if cert.DaysRemaining <= 14 {
-			warningCount++
-		} else if cert.DaysRemaining <= 30 {
-			cautionCount++
-		} else {
-			okCount++
-		}
-	}
-
-	body += `<div class="stats-container">`
-	body += fmt.Sprintf(`
-		<div class="stat-card">
-			<div class="stat-number">%d</div>
-			<div class="stat-label">Total Certificates</div>
-		</div>`, len(allCerts))
-	
-	if criticalCount > 0 {
-		body += fmt.Sprintf(`
-		<div class="stat-card" style="background: linear-gradient(135deg, #dc3545 0%, #c82333 100%);">
-			<div class="stat-number">%d</div>
-			<div class="stat-label">Critical (‚â§7 days)</div>
-		</div>`, criticalCount)
-	}
-	
-	if warningCount > 0 {
-		body += fmt.Sprintf(`
-		<div class="stat-card" style="background: linear-gradient(135deg, #ffc107 0%, #e0a800 100%);">
-			<div class="stat-number">%d</div>
-			<div class="stat-label">Warning (8-14 days)</div>
-		</div>`, warningCount)
+// describePublicKey reports a human-readable key type and size (in bits,
+// where applicable) for the algorithms Go's crypto/x509 can parse.
+func describePublicKey(pub interface{}) (string, int) {
+	switch key := pub.(type) {
+	case *rsa.PublicKey:
+		return "RSA", key.N.BitLen()
+	case *ecdsa.PublicKey:
+		return "ECDSA", key.Curve.Params().BitSize
+	case ed25519.PublicKey:
+		return "Ed25519", len(key) * 8
+	default:
+		return "unknown", 0
 	}
-	
-	body += `</div>`
-
-	// Expiring certificates section
-	if len(expiringCerts) > 0 {
-		body += `<div class="alert-section">
-			<h2><span style="font-size: 28px;">‚ö†Ô∏è</span> Certificates Expiring Soon</h2>
-			<p style="margin-bottom: 20px; opacity: 0.9;">The following certificates need immediate attention:</p>`
-
-		for _, cert := range expiringCerts {
-			badgeClass := "badge-warning"
-			cardClass := "warning"
-			if cert.DaysRemaining <= 7 {
-				badgeClass = "badge-critical"
-				cardClass = "critical"
-			}
-
-			body += fmt.Sprintf(`
-			<div class="cert-card %s" style="background: white; border-left-color: white;">
-				<div class="cert-header">
-					<div class="cert-name" style="color: #333;">%s</div>
-					<span class="cert-badge %s">%d days remaining</span>
-				</div>
-				<div class="cert-details">
-					<div class="cert-detail-item">
-						<span class="cert-detail-label">URL:</span>
-						<span>%s</span>
-					</div>
-					<div class="cert-detail-item">
-						<span class="cert-detail-label">Certificate:</span>
-						<span>%s</span>
-					</div>
-					<div class="cert-detail-item">
-						<span class="cert-detail-label">Expires:</span>
-						<span>%s</span>
-					</div>
-				</div>
-			</div>`,
-				cardClass, cert.Name, badgeClass, cert.DaysRemaining,
-				cert.URL, cert.CommonName, cert.ExpiryDate.Format("January 2, 2006"))
-		}
-
-		body += `</div>`
-	}
-
-	// All certificates section
-	body += `<h2 class="section-title">üìã All Monitored Certificates</h2>`
-
-	for _, cert := range allCerts {
-		badgeClass := "badge-ok"
-		cardClass := ""
-		badgeText := fmt.Sprintf("%d days", cert.DaysRemaining)
-
-		if cert.DaysRemaining <= 7 {
-			badgeClass = "badge-critical"
-			cardClass = "critical"
-		} else if cert.DaysRemaining <= 14 {
-			badgeClass = "badge-warning"
-			cardClass = "warning"
-		} else if cert.DaysRemaining <= 30 {
-			badgeClass = "badge-caution"
-			cardClass = "caution"
-		}
-
-		body += fmt.Sprintf(`
-		<div class="cert-card %s">
-			<div class="cert-header">
-				<div class="cert-name">%s</div>
-				<span class="cert-badge %s">%s</span>
-			</div>
-			<div class="cert-details">
-				<div class="cert-detail-item">
-					<span class="cert-detail-label">üåê URL:</span>
-					<span class="cert-url">%s</span>
-				</div>
-				<div class="cert-detail-item">
-					<span class="cert-detail-label">üìú Certificate:</span>
-					<span>%s</span>
-				</div>
-				<div class="cert-detail-item">
-					<span class="cert-detail-label">üìÖ Expires:</span>
-					<span>%s</span>
-				</div>
-			</div>
-		</div>`,
-			cardClass, cert.Name, badgeClass, badgeText,
-			cert.URL, cert.CommonName, cert.ExpiryDate.Format("January 2, 2006"))
-	}
-
-	body += `
-		</div>
-		<div class="footer">
-			<p>Automated SSL Certificate Monitoring System</p>
-			<p style="margin-top: 10px; font-size: 12px;">This is an automated notification. Please do not reply to this email.</p>
-		</div>
-	</div>
-</body>
-</html>`
-
-	return body
 }
 
-func sendSlackNotification(certs []CertInfo) {
-	slackWebhookURL := os.Getenv("SLACK_WEBHOOK_URL")
-
-	if slackWebhookURL == "" {
-		logger.Println("Slack webhook URL missing, skipping Slack notification")
-		return
-	}
-
-	message := "üö® *SSL Certificates Expiring Soon*\\n\\n"
-	for _, cert := range certs {
-		emoji := "‚ö†Ô∏è"
-		if cert.DaysRemaining <= 7 {
-			emoji = "üî¥"
-		}
-		message += fmt.Sprintf("%s *%s* (%s)\\n‚Ä¢ Certificate: %s\\n‚Ä¢ Days Remaining: *%d*\\n‚Ä¢ Expires: %s\\n\\n",
-			emoji, cert.Name, cert.URL, cert.CommonName, cert.DaysRemaining, cert.ExpiryDate.Format("2006-01-02"))
-	}
-
-	payload := map[string]interface{}{
-		"text": message,
-		"blocks": []map[string]interface{}{
-			{
-				"type": "header",
-				"text": map[string]string{
-					"type": "plain_text",
-					"text": "üö® SSL Certificates Expiring Soon",
-				},
-			},
-			{
-				"type": "section",
-				"text": map[string]string{
-					"type": "mrkdwn",
-					"text": message,
-				},
-			},
-		},
+// describeKeyLabel renders a cert's key type/size for display, e.g. "RSA-2048".
+func describeKeyLabel(cert CertInfo) string {
+	if cert.KeySize == 0 {
+		return cert.KeyType
 	}
+	return fmt.Sprintf("%s-%d", cert.KeyType, cert.KeySize)
+}
 
-	jsonData, err := json.Marshal(payload)
-	if err != nil {
-		logger.Printf("Error marshaling Slack payload: %v", err)
+// PrintCertsInfo prints a formatted table of every monitored endpoint and
+// its certificate details to w. It is intended to run once at startup so
+// operators can immediately verify the monitor's view of each host without
+// waiting for the first scheduled scan.
+func PrintCertsInfo(certs []CertInfo, w io.Writer) {
+	if len(certs) == 0 {
+		fmt.Fprintln(w, "No certificates to display")
 		return
 	}
 
-	resp, err := http.Post(slackWebhookURL, "application/json", bytes.NewBuffer(jsonData))
-	if err != nil {
-		logger.Printf("Error sending Slack notification: %v", err)
-		return
-	}
-	defer resp.Body.Close()
+	fmt.Fprintln(w, "SSL Certificate Monitor - Startup Summary")
+	fmt.Fprintln(w, strings.Repeat("=", 42))
 
-	if resp.StatusCode != http.StatusOK {
-		logger.Printf("Slack notification failed with status: %d", resp.StatusCode)
-		return
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tURL\tCOMMON NAME\tISSUER\tKEY\tSIG ALG\tEXPIRES\tDAYS LEFT")
+	for _, cert := range certs {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%d\n",
+			cert.Name, cert.URL, cert.CommonName, cert.Issuer, describeKeyLabel(cert),
+			cert.SignatureAlgorithm, cert.ExpiryDate.Format("2006-01-02"), cert.DaysRemaining)
 	}
-
-	logger.Println("Slack notification sent successfully")
+	tw.Flush()
 }
+
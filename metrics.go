@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// checkResult captures the outcome of probing a single configured endpoint,
+// independent of whether it succeeded, so metrics can report both.
+type checkResult struct {
+	Name     string
+	URL      string
+	Cert     CertInfo
+	Err      error
+	Duration time.Duration
+}
+
+// metricsSnapshot holds the most recently rendered /metrics payload, so a
+// scrape always sees the consistent output of one whole scan rather than a
+// mix of two concurrent ones.
+var metricsSnapshot atomic.Value
+
+func init() {
+	metricsSnapshot.Store("")
+}
+
+// MetricsServer exposes the latest scan as Prometheus text-format metrics on
+// /metrics, plus a /healthz liveness probe, so the monitor can be alerted on
+// via Alertmanager instead of only email/Slack.
+type MetricsServer struct {
+	Addr string
+}
+
+// newMetricsServerFromEnv returns a MetricsServer if METRICS_ADDR is
+// configured, or nil if the metrics endpoint is disabled.
+func newMetricsServerFromEnv() *MetricsServer {
+	addr := os.Getenv("METRICS_ADDR")
+	if addr == "" {
+		return nil
+	}
+	return &MetricsServer{Addr: addr}
+}
+
+// Start runs the metrics HTTP server in the background and returns
+// immediately; listener errors are logged rather than fatal, since metrics
+// are a diagnostic aid and shouldn't take down the monitor itself.
+func (s *MetricsServer) Start() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", handleMetrics)
+	mux.HandleFunc("/healthz", handleHealthz)
+
+	server := &http.Server{
+		Addr:         s.Addr,
+		Handler:      mux,
+		ReadTimeout:  5 * time.Second,
+		WriteTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		logger.Printf("Metrics server listening on %s", s.Addr)
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logger.Printf("Metrics server error: %v", err)
+		}
+	}()
+}
+
+func handleMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	fmt.Fprint(w, metricsSnapshot.Load().(string))
+}
+
+func handleHealthz(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	fmt.Fprint(w, "ok")
+}
+
+// recordMetrics renders a scan's results into Prometheus text format and
+// atomically swaps the exposed snapshot, so /metrics never shows a partial
+// update from an in-progress scan.
+func recordMetrics(results []checkResult) {
+	var b strings.Builder
+
+	b.WriteString("# HELP ssl_cert_days_remaining Days remaining until certificate expiry\n")
+	b.WriteString("# TYPE ssl_cert_days_remaining gauge\n")
+	for _, r := range results {
+		if r.Err != nil {
+			continue
+		}
+		fmt.Fprintf(&b, "ssl_cert_days_remaining{name=%q,url=%q,common_name=%q,issuer=%q} %d\n",
+			r.Name, r.URL, r.Cert.CommonName, r.Cert.Issuer, r.Cert.DaysRemaining)
+	}
+
+	b.WriteString("# HELP ssl_cert_not_after_timestamp Certificate expiry as a Unix timestamp\n")
+	b.WriteString("# TYPE ssl_cert_not_after_timestamp gauge\n")
+	for _, r := range results {
+		if r.Err != nil {
+			continue
+		}
+		fmt.Fprintf(&b, "ssl_cert_not_after_timestamp{name=%q,url=%q,common_name=%q,issuer=%q} %d\n",
+			r.Name, r.URL, r.Cert.CommonName, r.Cert.Issuer, r.Cert.ExpiryDate.Unix())
+	}
+
+	b.WriteString("# HELP ssl_cert_check_success Whether the most recent check of this endpoint succeeded\n")
+	b.WriteString("# TYPE ssl_cert_check_success gauge\n")
+	for _, r := range results {
+		success := 1
+		if r.Err != nil {
+			success = 0
+		}
+		fmt.Fprintf(&b, "ssl_cert_check_success{name=%q,url=%q} %d\n", r.Name, r.URL, success)
+	}
+
+	b.WriteString("# HELP ssl_cert_check_duration_seconds How long the most recent check of this endpoint took\n")
+	b.WriteString("# TYPE ssl_cert_check_duration_seconds gauge\n")
+	for _, r := range results {
+		fmt.Fprintf(&b, "ssl_cert_check_duration_seconds{name=%q,url=%q} %f\n", r.Name, r.URL, r.Duration.Seconds())
+	}
+
+	metricsSnapshot.Store(b.String())
+}
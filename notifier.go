@@ -0,0 +1,620 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"embed"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"net/smtp"
+	"net/textproto"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed assets/logo.png assets/icon-critical.png assets/icon-warning.png assets/icon-caution.png assets/icon-ok.png
+var embeddedAssets embed.FS
+
+// Report is the data handed to every Notifier on each scan.
+type Report struct {
+	AllCerts      []CertInfo
+	ExpiringCerts []CertInfo
+	GeneratedAt   time.Time
+}
+
+// Notifier delivers a Report through some channel (email, chat, webhook...).
+// Implementations should no-op rather than error when the report has
+// nothing worth reporting for that channel.
+type Notifier interface {
+	Notify(ctx context.Context, report Report) error
+}
+
+// loadNotifiers builds the set of notifiers enabled via environment
+// configuration. A notifier is included only if its required config is
+// present, mirroring the "skip silently if unconfigured" behavior the
+// original sendEmail/sendSlackNotification functions had.
+func loadNotifiers() []Notifier {
+	var notifiers []Notifier
+
+	if n := newSMTPNotifierFromEnv(); n != nil {
+		notifiers = append(notifiers, n)
+	}
+	if n := newSlackNotifierFromEnv(); n != nil {
+		notifiers = append(notifiers, n)
+	}
+	if n := newTeamsNotifierFromEnv(); n != nil {
+		notifiers = append(notifiers, n)
+	}
+	if n := newPagerDutyNotifierFromEnv(); n != nil {
+		notifiers = append(notifiers, n)
+	}
+	if n := newWebhookNotifierFromEnv(); n != nil {
+		notifiers = append(notifiers, n)
+	}
+
+	return notifiers
+}
+
+func orDefault(v, def string) string {
+	if v == "" {
+		return def
+	}
+	return v
+}
+
+// --- SMTP ---------------------------------------------------------------
+
+type smtpTLSMode string
+
+const (
+	smtpTLSNone     smtpTLSMode = "none"
+	smtpTLSStartTLS smtpTLSMode = "starttls"
+	smtpTLSImplicit smtpTLSMode = "implicit"
+)
+
+type smtpAuthMechanism string
+
+const (
+	smtpAuthNone    smtpAuthMechanism = "NONE"
+	smtpAuthPlain   smtpAuthMechanism = "PLAIN"
+	smtpAuthLogin   smtpAuthMechanism = "LOGIN"
+	smtpAuthCRAMMD5 smtpAuthMechanism = "CRAM-MD5"
+)
+
+// SMTPNotifier emails the full certificate report as a multipart/alternative
+// (text + HTML) message. When EmbedImages is set, the logo and status icons
+// are attached as multipart/related parts and referenced via cid: URLs;
+// otherwise they're linked as hosted https:// URLs.
+type SMTPNotifier struct {
+	Host          string
+	Port          string
+	User          string
+	Pass          string
+	From          string
+	To            []string
+	HELOName      string
+	TLSMode       smtpTLSMode
+	AuthMechanism smtpAuthMechanism
+	EmbedImages   bool
+	Timeout       time.Duration
+}
+
+func newSMTPNotifierFromEnv() *SMTPNotifier {
+	host := os.Getenv("SMTP_HOST")
+	port := os.Getenv("SMTP_PORT")
+	from := os.Getenv("EMAIL_FROM")
+	to := os.Getenv("EMAIL_TO")
+
+	if host == "" || port == "" || from == "" || to == "" {
+		return nil
+	}
+
+	timeout := 10 * time.Second
+	if v := os.Getenv("SMTP_TIMEOUT_SECONDS"); v != "" {
+		if secs, err := strconv.Atoi(v); err == nil && secs > 0 {
+			timeout = time.Duration(secs) * time.Second
+		}
+	}
+
+	return &SMTPNotifier{
+		Host:          host,
+		Port:          port,
+		User:          os.Getenv("SMTP_USER"),
+		Pass:          os.Getenv("SMTP_PASS"),
+		From:          from,
+		To:            strings.Split(to, ","),
+		HELOName:      os.Getenv("SMTP_HELO"),
+		TLSMode:       smtpTLSMode(strings.ToLower(orDefault(os.Getenv("SMTP_TLS_MODE"), string(smtpTLSStartTLS)))),
+		AuthMechanism: smtpAuthMechanism(strings.ToUpper(orDefault(os.Getenv("SMTP_AUTH_MECHANISM"), string(smtpAuthPlain)))),
+		EmbedImages:   os.Getenv("SMTP_EMBED_IMAGES") == "true",
+		Timeout:       timeout,
+	}
+}
+
+func (n *SMTPNotifier) Notify(ctx context.Context, report Report) error {
+	if len(report.AllCerts) == 0 {
+		return nil
+	}
+
+	msg, err := n.buildMessage(report)
+	if err != nil {
+		return fmt.Errorf("building email: %w", err)
+	}
+
+	if err := n.send(ctx, msg); err != nil {
+		return fmt.Errorf("sending email: %w", err)
+	}
+
+	logger.Println("Email sent successfully")
+	return nil
+}
+
+func (n *SMTPNotifier) send(ctx context.Context, msg []byte) error {
+	addr := net.JoinHostPort(n.Host, n.Port)
+
+	dialer := &net.Dialer{Timeout: n.Timeout}
+
+	var conn net.Conn
+	var err error
+	if n.TLSMode == smtpTLSImplicit {
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, &tls.Config{ServerName: n.Host})
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return fmt.Errorf("dialing %s: %w", addr, err)
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	} else {
+		conn.SetDeadline(time.Now().Add(n.Timeout))
+	}
+
+	client, err := smtp.NewClient(conn, n.Host)
+	if err != nil {
+		return fmt.Errorf("creating SMTP client: %w", err)
+	}
+	defer client.Close()
+
+	if n.HELOName != "" {
+		if err := client.Hello(n.HELOName); err != nil {
+			return fmt.Errorf("HELO: %w", err)
+		}
+	}
+
+	if n.TLSMode == smtpTLSStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return fmt.Errorf("STARTTLS requested but %s does not advertise it", addr)
+		}
+		if err := client.StartTLS(&tls.Config{ServerName: n.Host}); err != nil {
+			return fmt.Errorf("STARTTLS: %w", err)
+		}
+	}
+
+	if auth, err := n.auth(client); err != nil {
+		return err
+	} else if auth != nil {
+		if err := client.Auth(auth); err != nil {
+			return fmt.Errorf("SMTP auth: %w", err)
+		}
+	}
+
+	if err := client.Mail(n.From); err != nil {
+		return fmt.Errorf("MAIL FROM: %w", err)
+	}
+	for _, rcpt := range n.To {
+		if err := client.Rcpt(strings.TrimSpace(rcpt)); err != nil {
+			return fmt.Errorf("RCPT TO %s: %w", rcpt, err)
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("DATA: %w", err)
+	}
+	if _, err := w.Write(msg); err != nil {
+		return fmt.Errorf("writing message: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+func (n *SMTPNotifier) auth(client *smtp.Client) (smtp.Auth, error) {
+	if n.User == "" {
+		return nil, nil
+	}
+
+	switch n.AuthMechanism {
+	case smtpAuthPlain:
+		return smtp.PlainAuth("", n.User, n.Pass, n.Host), nil
+	case smtpAuthLogin:
+		return &loginAuth{username: n.User, password: n.Pass}, nil
+	case smtpAuthCRAMMD5:
+		return smtp.CRAMMD5Auth(n.User, n.Pass), nil
+	case smtpAuthNone:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unsupported SMTP_AUTH_MECHANISM %q", n.AuthMechanism)
+	}
+}
+
+// loginAuth implements the SMTP LOGIN auth mechanism, which net/smtp does
+// not provide out of the box.
+type loginAuth struct {
+	username string
+	password string
+}
+
+func (a *loginAuth) Start(_ *smtp.ServerInfo) (string, []byte, error) {
+	return "LOGIN", nil, nil
+}
+
+func (a *loginAuth) Next(fromServer []byte, more bool) ([]byte, error) {
+	if !more {
+		return nil, nil
+	}
+	switch strings.ToLower(strings.TrimSuffix(string(fromServer), ":")) {
+	case "username":
+		return []byte(a.username), nil
+	case "password":
+		return []byte(a.password), nil
+	default:
+		return nil, fmt.Errorf("unexpected LOGIN auth prompt: %q", fromServer)
+	}
+}
+
+func (n *SMTPNotifier) buildMessage(report Report) ([]byte, error) {
+	htmlBody := buildEmailHTMLBody(report.AllCerts, report.ExpiringCerts, n.EmbedImages)
+	textBody := buildEmailTextBody(report.AllCerts, report.ExpiringCerts)
+
+	altBuf := &bytes.Buffer{}
+	altWriter := multipart.NewWriter(altBuf)
+	if err := writeMIMEPart(altWriter, "text/plain", textBody); err != nil {
+		return nil, err
+	}
+	if err := writeMIMEPart(altWriter, "text/html", htmlBody); err != nil {
+		return nil, err
+	}
+	if err := altWriter.Close(); err != nil {
+		return nil, err
+	}
+
+	headers := textproto.MIMEHeader{}
+	headers.Set("From", n.From)
+	headers.Set("To", strings.Join(n.To, ", "))
+	headers.Set("Subject", mime.QEncoding.Encode("UTF-8", "SSL Certificate Monitoring Report"))
+	headers.Set("Date", time.Now().Format(time.RFC1123Z))
+	headers.Set("MIME-Version", "1.0")
+
+	var buf bytes.Buffer
+	if !n.EmbedImages {
+		headers.Set("Content-Type", fmt.Sprintf(`multipart/alternative; boundary="%s"`, altWriter.Boundary()))
+		writeMIMEHeaders(&buf, headers)
+		buf.Write(altBuf.Bytes())
+		return buf.Bytes(), nil
+	}
+
+	relatedBuf := &bytes.Buffer{}
+	relatedWriter := multipart.NewWriter(relatedBuf)
+
+	altPart, err := relatedWriter.CreatePart(textproto.MIMEHeader{
+		"Content-Type": {fmt.Sprintf(`multipart/alternative; boundary="%s"`, altWriter.Boundary())},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := altPart.Write(altBuf.Bytes()); err != nil {
+		return nil, err
+	}
+
+	for _, asset := range embeddedImageAssets() {
+		part, err := relatedWriter.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {"image/png"},
+			"Content-Transfer-Encoding": {"base64"},
+			"Content-ID":                {fmt.Sprintf("<%s>", asset.cid)},
+			"Content-Disposition":       {fmt.Sprintf(`inline; filename="%s"`, asset.filename)},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := part.Write([]byte(base64.StdEncoding.EncodeToString(asset.data))); err != nil {
+			return nil, err
+		}
+	}
+	if err := relatedWriter.Close(); err != nil {
+		return nil, err
+	}
+
+	headers.Set("Content-Type", fmt.Sprintf(`multipart/related; boundary="%s"`, relatedWriter.Boundary()))
+	writeMIMEHeaders(&buf, headers)
+	buf.Write(relatedBuf.Bytes())
+	return buf.Bytes(), nil
+}
+
+func writeMIMEPart(w *multipart.Writer, contentType, body string) error {
+	part, err := w.CreatePart(textproto.MIMEHeader{
+		"Content-Type": {fmt.Sprintf(`%s; charset="UTF-8"`, contentType)},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = part.Write([]byte(body))
+	return err
+}
+
+func writeMIMEHeaders(buf *bytes.Buffer, headers textproto.MIMEHeader) {
+	for _, key := range []string{"From", "To", "Subject", "Date", "MIME-Version", "Content-Type"} {
+		if v := headers.Get(key); v != "" {
+			fmt.Fprintf(buf, "%s: %s\r\n", key, v)
+		}
+	}
+	buf.WriteString("\r\n")
+}
+
+type emailAsset struct {
+	cid      string
+	filename string
+	data     []byte
+}
+
+func embeddedImageAssets() []emailAsset {
+	files := []struct{ cid, name string }{
+		{"logo", "logo.png"},
+		{"icon-critical", "icon-critical.png"},
+		{"icon-warning", "icon-warning.png"},
+		{"icon-caution", "icon-caution.png"},
+		{"icon-ok", "icon-ok.png"},
+	}
+
+	assets := make([]emailAsset, 0, len(files))
+	for _, f := range files {
+		data, err := embeddedAssets.ReadFile("assets/" + f.name)
+		if err != nil {
+			logger.Printf("Error reading embedded asset %s: %v", f.name, err)
+			continue
+		}
+		assets = append(assets, emailAsset{cid: f.cid, filename: f.name, data: data})
+	}
+	return assets
+}
+
+// assetSrc returns the <img> src for a bundled asset: a cid: reference when
+// embedding, or a hosted URL (overridable via ASSET_BASE_URL) otherwise.
+func assetSrc(filename, cid string, embedImages bool) string {
+	if embedImages {
+		return "cid:" + cid
+	}
+	base := orDefault(os.Getenv("ASSET_BASE_URL"), "https://raw.githubusercontent.com/zablonjames/url-ssl-check/main/assets")
+	return strings.TrimRight(base, "/") + "/" + filename
+}
+
+// --- Slack ---------------------------------------------------------------
+
+// SlackNotifier posts expiring certificates to a Slack incoming webhook.
+type SlackNotifier struct {
+	WebhookURL string
+}
+
+func newSlackNotifierFromEnv() *SlackNotifier {
+	url := os.Getenv("SLACK_WEBHOOK_URL")
+	if url == "" {
+		return nil
+	}
+	return &SlackNotifier{WebhookURL: url}
+}
+
+func (n *SlackNotifier) Notify(ctx context.Context, report Report) error {
+	if len(report.ExpiringCerts) == 0 {
+		return nil
+	}
+
+	payload := map[string]interface{}{
+		"text": slackMessageText(report.ExpiringCerts),
+		"blocks": []map[string]interface{}{
+			{
+				"type": "header",
+				"text": map[string]string{
+					"type": "plain_text",
+					"text": "🚨 SSL Certificates Expiring Soon",
+				},
+			},
+			{
+				"type": "section",
+				"text": map[string]string{
+					"type": "mrkdwn",
+					"text": slackMessageText(report.ExpiringCerts),
+				},
+			},
+		},
+	}
+
+	if err := postJSON(ctx, n.WebhookURL, payload); err != nil {
+		return fmt.Errorf("sending Slack notification: %w", err)
+	}
+
+	logger.Println("Slack notification sent successfully")
+	return nil
+}
+
+func slackMessageText(certs []CertInfo) string {
+	message := "🚨 *SSL Certificates Expiring Soon*\\n\\n"
+	for _, cert := range certs {
+		emoji := "⚠️"
+		if cert.DaysRemaining <= 7 {
+			emoji = "🔴"
+		}
+		status := ""
+		if cert.Status != "" && cert.Status != CertStatusOK {
+			status = fmt.Sprintf("\\n• Status: *%s*", describeCertStatus(cert.Status))
+		}
+		message += fmt.Sprintf("%s *%s* (%s)\\n• Certificate: %s\\n• Issuer: %s\\n• Key: %s\\n• Serial: %s\\n• SANs: %s\\n• Days Remaining: *%d*\\n• Expires: %s%s\\n\\n",
+			emoji, cert.Name, cert.URL, cert.CommonName, cert.Issuer, describeKeyLabel(cert),
+			cert.SerialNumber, formatSANs(cert.SANs), cert.DaysRemaining, cert.ExpiryDate.Format("2006-01-02"), status)
+	}
+	return message
+}
+
+// --- Microsoft Teams -------------------------------------------------------
+
+// TeamsNotifier posts expiring certificates to a Microsoft Teams incoming
+// webhook connector using the legacy "MessageCard" format.
+type TeamsNotifier struct {
+	WebhookURL string
+}
+
+func newTeamsNotifierFromEnv() *TeamsNotifier {
+	url := os.Getenv("TEAMS_WEBHOOK_URL")
+	if url == "" {
+		return nil
+	}
+	return &TeamsNotifier{WebhookURL: url}
+}
+
+func (n *TeamsNotifier) Notify(ctx context.Context, report Report) error {
+	if len(report.ExpiringCerts) == 0 {
+		return nil
+	}
+
+	facts := make([]map[string]string, 0, len(report.ExpiringCerts))
+	for _, cert := range report.ExpiringCerts {
+		facts = append(facts, map[string]string{
+			"name":  fmt.Sprintf("%s (%s)", cert.Name, cert.URL),
+			"value": fmt.Sprintf("%d days remaining, expires %s", cert.DaysRemaining, cert.ExpiryDate.Format("2006-01-02")),
+		})
+	}
+
+	payload := map[string]interface{}{
+		"@type":      "MessageCard",
+		"@context":   "http://schema.org/extensions",
+		"themeColor": "DC3545",
+		"summary":    "SSL Certificates Expiring Soon",
+		"sections": []map[string]interface{}{
+			{
+				"activityTitle": "🚨 SSL Certificates Expiring Soon",
+				"facts":         facts,
+			},
+		},
+	}
+
+	if err := postJSON(ctx, n.WebhookURL, payload); err != nil {
+		return fmt.Errorf("sending Teams notification: %w", err)
+	}
+
+	logger.Println("Teams notification sent successfully")
+	return nil
+}
+
+// --- PagerDuty -------------------------------------------------------------
+
+const pagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+// PagerDutyNotifier triggers a PagerDuty Events API v2 alert when
+// certificates are expiring soon.
+type PagerDutyNotifier struct {
+	RoutingKey string
+}
+
+func newPagerDutyNotifierFromEnv() *PagerDutyNotifier {
+	key := os.Getenv("PAGERDUTY_ROUTING_KEY")
+	if key == "" {
+		return nil
+	}
+	return &PagerDutyNotifier{RoutingKey: key}
+}
+
+func (n *PagerDutyNotifier) Notify(ctx context.Context, report Report) error {
+	if len(report.ExpiringCerts) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(report.ExpiringCerts))
+	for _, cert := range report.ExpiringCerts {
+		names = append(names, fmt.Sprintf("%s (%d days)", cert.Name, cert.DaysRemaining))
+	}
+
+	payload := map[string]interface{}{
+		"routing_key":  n.RoutingKey,
+		"event_action": "trigger",
+		"dedup_key":    "ssl-cert-expiry",
+		"payload": map[string]interface{}{
+			"summary":   fmt.Sprintf("%d SSL certificate(s) expiring soon: %s", len(report.ExpiringCerts), strings.Join(names, ", ")),
+			"source":    "url-ssl-check",
+			"severity":  "warning",
+			"timestamp": report.GeneratedAt.Format(time.RFC3339),
+		},
+	}
+
+	if err := postJSON(ctx, pagerDutyEventsURL, payload); err != nil {
+		return fmt.Errorf("sending PagerDuty event: %w", err)
+	}
+
+	logger.Println("PagerDuty event sent successfully")
+	return nil
+}
+
+// --- Generic webhook ---------------------------------------------------
+
+// WebhookNotifier POSTs the full report as JSON to an arbitrary endpoint,
+// for operators wiring this into their own alerting pipeline.
+type WebhookNotifier struct {
+	URL string
+}
+
+func newWebhookNotifierFromEnv() *WebhookNotifier {
+	url := os.Getenv("WEBHOOK_URL")
+	if url == "" {
+		return nil
+	}
+	return &WebhookNotifier{URL: url}
+}
+
+func (n *WebhookNotifier) Notify(ctx context.Context, report Report) error {
+	if len(report.AllCerts) == 0 {
+		return nil
+	}
+
+	if err := postJSON(ctx, n.URL, report); err != nil {
+		return fmt.Errorf("sending webhook notification: %w", err)
+	}
+
+	logger.Println("Webhook notification sent successfully")
+	return nil
+}
+
+func postJSON(ctx context.Context, url string, payload interface{}) error {
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshaling payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,530 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ScanTarget is one endpoint to probe, with any per-host dial overrides on
+// top of the bare name/URL that getURLsFromEnv and loadTargetsFromStore used
+// to produce.
+type ScanTarget struct {
+	Name       string
+	URL        string
+	ServerName string
+
+	// Protocol selects how the connection negotiates TLS: "https" (or
+	// empty) dials TLS directly, while "smtp", "smtp-submission", "imap",
+	// "pop3", "ldap", and "postgres" connect in plaintext first and
+	// negotiate STARTTLS (or postgres's SSLRequest) before the handshake.
+	Protocol string
+
+	ClientCertFile string
+	ClientKeyFile  string
+
+	MinVersion   uint16
+	CipherSuites []uint16
+}
+
+// ScanConfig controls how checkCertificates probes its targets.
+type ScanConfig struct {
+	Concurrency int
+	Timeout     time.Duration
+	MaxRetries  int
+	RetryBase   time.Duration
+}
+
+// scanConfigFromEnv reads SCAN_CONCURRENCY and SCAN_TIMEOUT, falling back to
+// defaults chosen to be safe for a few hundred endpoints behind typical
+// network conditions.
+func scanConfigFromEnv() ScanConfig {
+	cfg := ScanConfig{
+		Concurrency: 16,
+		Timeout:     10 * time.Second,
+		MaxRetries:  2,
+		RetryBase:   500 * time.Millisecond,
+	}
+
+	if v := os.Getenv("SCAN_CONCURRENCY"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			cfg.Concurrency = n
+		}
+	}
+	if v := os.Getenv("SCAN_TIMEOUT"); v != "" {
+		if secs, err := strconv.Atoi(v); err == nil && secs > 0 {
+			cfg.Timeout = time.Duration(secs) * time.Second
+		}
+	}
+
+	return cfg
+}
+
+// runScans probes every target through a worker pool of cfg.Concurrency
+// goroutines, so one hung host can no longer stall the rest of the scan.
+// Results are returned in the same order as targets.
+func runScans(ctx context.Context, targets []ScanTarget, cfg ScanConfig) []checkResult {
+	results := make([]checkResult, len(targets))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	workers := cfg.Concurrency
+	if workers > len(targets) {
+		workers = len(targets)
+	}
+	if workers < 1 {
+		workers = 1
+	}
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				target := targets[i]
+				start := time.Now()
+				cert, err := scanWithRetry(ctx, target, cfg)
+				results[i] = checkResult{Name: target.Name, URL: target.URL, Cert: cert, Err: err, Duration: time.Since(start)}
+			}
+		}()
+	}
+
+	for i := range targets {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+// scanWithRetry probes target, retrying transient (network/timeout) errors
+// with exponential backoff. Certificate validation failures are not
+// retried, since a repeat dial won't change a host's certificate.
+func scanWithRetry(ctx context.Context, target ScanTarget, cfg ScanConfig) (CertInfo, error) {
+	var lastErr error
+
+	for attempt := 0; attempt <= cfg.MaxRetries; attempt++ {
+		if attempt > 0 {
+			backoff := cfg.RetryBase * time.Duration(1<<uint(attempt-1))
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return CertInfo{}, ctx.Err()
+			}
+		}
+
+		cert, err := dialAndInspect(ctx, target, cfg.Timeout)
+		if err == nil {
+			return cert, nil
+		}
+		lastErr = err
+		if !isRetryableScanError(err) {
+			return CertInfo{}, err
+		}
+	}
+
+	return CertInfo{}, fmt.Errorf("after %d attempts: %w", cfg.MaxRetries+1, lastErr)
+}
+
+// isRetryableScanError reports whether err looks transient (dial timeout,
+// connection refused/reset, DNS hiccup) rather than a configuration problem
+// a retry can't fix. Certificate validation is no longer one of those
+// problems: dialAndInspect classifies it into CertStatus instead of failing
+// the dial, so it never reaches here.
+func isRetryableScanError(err error) bool {
+	var permErr *permanentScanError
+	return !errors.As(err, &permErr)
+}
+
+// permanentScanError marks a scan failure as a configuration problem (bad
+// protocol, unreadable client certificate, rejected STARTTLS negotiation)
+// that repeating the dial won't fix.
+type permanentScanError struct {
+	err error
+}
+
+func (e *permanentScanError) Error() string { return e.err.Error() }
+func (e *permanentScanError) Unwrap() error { return e.err }
+
+// defaultPorts gives the well-known port for each supported Protocol, used
+// when a target's URL doesn't already specify one.
+var defaultPorts = map[string]string{
+	"":                "443",
+	"https":           "443",
+	"smtp":            "25",
+	"smtp-submission": "587",
+	"imap":            "143",
+	"pop3":            "110",
+	"ldap":            "389",
+	"postgres":        "5432",
+}
+
+// starttlsProbes negotiates TLS on an established plaintext connection for
+// protocols that don't dial TLS directly: each sends whatever plaintext
+// handshake that protocol requires and returns once the peer has agreed to
+// upgrade, so the caller can wrap conn in a tls.Conn.
+var starttlsProbes = map[string]func(conn net.Conn) error{
+	"smtp":            smtpStartTLS,
+	"smtp-submission": smtpStartTLS,
+	"imap":            imapStartTLS,
+	"pop3":            pop3StartTLS,
+	"ldap":            ldapStartTLS,
+	"postgres":        postgresSSLRequest,
+}
+
+// dialAndInspect connects to target per its Protocol (a direct TLS dial for
+// "https", or a plaintext connection upgraded via STARTTLS/SSLRequest for
+// the mail/directory/database protocols), honoring its SNI override, client
+// certificate, and MinVersion/CipherSuites override, and returns the parsed
+// leaf certificate, chain, and validation status.
+//
+// Verification is done ourselves via classifyCertStatus rather than left to
+// the TLS handshake, so an expired or self-signed certificate still yields
+// a CertInfo with a specific CertStatus instead of a single opaque dial
+// error.
+func dialAndInspect(ctx context.Context, target ScanTarget, timeout time.Duration) (CertInfo, error) {
+	protocol := orDefault(target.Protocol, "https")
+	host := normalizeHost(target.URL, protocol)
+
+	tlsConfig := &tls.Config{
+		ServerName:         verifyHostname(target, host),
+		MinVersion:         target.MinVersion,
+		CipherSuites:       target.CipherSuites,
+		InsecureSkipVerify: true,
+	}
+
+	if target.ClientCertFile != "" {
+		cert, err := tls.LoadX509KeyPair(target.ClientCertFile, target.ClientKeyFile)
+		if err != nil {
+			return CertInfo{}, &permanentScanError{fmt.Errorf("loading client certificate: %w", err)}
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	dialCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	rawConn, err := (&net.Dialer{}).DialContext(dialCtx, "tcp", host)
+	if err != nil {
+		return CertInfo{}, err
+	}
+	defer rawConn.Close()
+
+	if deadline, ok := dialCtx.Deadline(); ok {
+		rawConn.SetDeadline(deadline)
+	}
+
+	if probe, ok := starttlsProbes[protocol]; ok {
+		if err := probe(rawConn); err != nil {
+			return CertInfo{}, &permanentScanError{fmt.Errorf("negotiating STARTTLS: %w", err)}
+		}
+	} else if protocol != "https" {
+		return CertInfo{}, &permanentScanError{fmt.Errorf("unsupported protocol %q", protocol)}
+	}
+
+	tlsConn := tls.Client(rawConn, tlsConfig)
+	if err := tlsConn.HandshakeContext(dialCtx); err != nil {
+		return CertInfo{}, err
+	}
+
+	certs := tlsConn.ConnectionState().PeerCertificates
+	if len(certs) == 0 {
+		return CertInfo{}, fmt.Errorf("no certificates found")
+	}
+
+	info := parseCertInfo(certs, host, target.Name)
+	info.Status = classifyCertStatus(certs, verifyHostname(target, host))
+	return info, nil
+}
+
+// verifyHostname returns the name a target's certificate should be checked
+// against: its SNI override when set, otherwise the dial host with any port
+// stripped.
+func verifyHostname(target ScanTarget, host string) string {
+	if target.ServerName != "" {
+		return target.ServerName
+	}
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		return h
+	}
+	return host
+}
+
+// classifyCertStatus inspects a peer's certificate chain and reports the
+// single most relevant CertStatus, checked in order of how actionable it is
+// to an operator: an expired cert is worth knowing about even if the chain
+// also happens to be self-signed.
+func classifyCertStatus(certs []*x509.Certificate, hostname string) CertStatus {
+	leaf := certs[0]
+	now := time.Now()
+
+	if now.After(leaf.NotAfter) {
+		return CertStatusExpiredLeaf
+	}
+	for _, c := range certs[1:] {
+		if now.After(c.NotAfter) {
+			return CertStatusExpiredIntermediate
+		}
+	}
+	if leaf.VerifyHostname(hostname) != nil {
+		return CertStatusHostnameMismatch
+	}
+	if isSelfSigned(leaf) {
+		return CertStatusSelfSigned
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, c := range certs[1:] {
+		intermediates.AddCert(c)
+	}
+	if _, err := leaf.Verify(x509.VerifyOptions{DNSName: hostname, Intermediates: intermediates}); err != nil {
+		return CertStatusUnknownIssuer
+	}
+	return CertStatusOK
+}
+
+// isSelfSigned reports whether cert was signed by its own key, i.e. it is
+// its own trust anchor rather than issued by a separate CA. This checks the
+// signature directly with CheckSignature rather than CheckSignatureFrom,
+// since the latter also enforces CA basic-constraints/KeyUsage and would
+// reject the common case of a self-signed leaf cert that doesn't set them
+// (the default shape produced by e.g. "openssl req -x509").
+func isSelfSigned(cert *x509.Certificate) bool {
+	if !bytes.Equal(cert.RawIssuer, cert.RawSubject) {
+		return false
+	}
+	return cert.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature) == nil
+}
+
+// normalizeHost strips any scheme and adds protocol's default port,
+// mirroring the legacy getCertificateInfo behavior for plain "host" or
+// "host:port" entries.
+func normalizeHost(url, protocol string) string {
+	host := strings.TrimPrefix(url, "https://")
+	host = strings.TrimPrefix(host, "http://")
+	if !strings.Contains(host, ":") {
+		port := defaultPorts[protocol]
+		if port == "" {
+			port = "443"
+		}
+		host += ":" + port
+	}
+	return host
+}
+
+// writeLine writes s terminated with a CRLF, as the line-oriented STARTTLS
+// protocols below expect.
+func writeLine(conn net.Conn, s string) error {
+	_, err := conn.Write([]byte(s + "\r\n"))
+	return err
+}
+
+// smtpStartTLS negotiates STARTTLS per RFC 3207: read the banner, EHLO,
+// request STARTTLS, and expect a 220 response before handing back to the
+// caller to begin the TLS handshake. Used for smtp:25 and smtp-submission:587.
+func smtpStartTLS(conn net.Conn) error {
+	r := bufio.NewReader(conn)
+
+	if _, err := readSMTPResponse(r); err != nil {
+		return fmt.Errorf("reading banner: %w", err)
+	}
+	if err := writeLine(conn, "EHLO url-ssl-check"); err != nil {
+		return err
+	}
+	if _, err := readSMTPResponse(r); err != nil {
+		return fmt.Errorf("EHLO: %w", err)
+	}
+	if err := writeLine(conn, "STARTTLS"); err != nil {
+		return err
+	}
+	code, err := readSMTPResponse(r)
+	if err != nil {
+		return fmt.Errorf("STARTTLS: %w", err)
+	}
+	if code != 220 {
+		return fmt.Errorf("STARTTLS rejected with code %d", code)
+	}
+	return nil
+}
+
+// readSMTPResponse reads one (possibly multiline) SMTP reply and returns its
+// three-digit status code; multiline replies use "-" instead of " " after
+// the code on every line but the last.
+func readSMTPResponse(r *bufio.Reader) (int, error) {
+	var code int
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return 0, err
+		}
+		if len(line) < 4 {
+			return 0, fmt.Errorf("malformed response %q", line)
+		}
+		code, err = strconv.Atoi(line[:3])
+		if err != nil {
+			return 0, fmt.Errorf("malformed response code %q", line[:3])
+		}
+		if line[3] == ' ' {
+			return code, nil
+		}
+	}
+}
+
+// imapStartTLS negotiates STARTTLS per RFC 3501: read the greeting, issue a
+// tagged STARTTLS command, and expect a tagged OK before the TLS handshake.
+// Used for imap:143.
+func imapStartTLS(conn net.Conn) error {
+	r := bufio.NewReader(conn)
+
+	if _, err := r.ReadString('\n'); err != nil {
+		return fmt.Errorf("reading greeting: %w", err)
+	}
+	if err := writeLine(conn, "a1 STARTTLS"); err != nil {
+		return err
+	}
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return fmt.Errorf("STARTTLS: %w", err)
+	}
+	if !strings.HasPrefix(line, "a1 OK") {
+		return fmt.Errorf("STARTTLS rejected: %q", strings.TrimSpace(line))
+	}
+	return nil
+}
+
+// pop3StartTLS negotiates STLS per RFC 2595: read the greeting, issue STLS,
+// and expect +OK before the TLS handshake. Used for pop3:110.
+func pop3StartTLS(conn net.Conn) error {
+	r := bufio.NewReader(conn)
+
+	if _, err := r.ReadString('\n'); err != nil {
+		return fmt.Errorf("reading greeting: %w", err)
+	}
+	if err := writeLine(conn, "STLS"); err != nil {
+		return err
+	}
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return fmt.Errorf("STLS: %w", err)
+	}
+	if !strings.HasPrefix(line, "+OK") {
+		return fmt.Errorf("STLS rejected: %q", strings.TrimSpace(line))
+	}
+	return nil
+}
+
+// ldapExtendedRequestOID is the LDAPv3 "Start TLS" extended operation OID
+// (RFC 4511 section 4.14.1).
+const ldapExtendedRequestOID = "1.3.6.1.4.1.1466.20037"
+
+// ldapStartTLS sends a minimal BER-encoded LDAPMessage wrapping a Start TLS
+// ExtendedRequest and checks that the ExtendedResponse's resultCode is
+// success (0) before the TLS handshake. Used for ldap:389.
+func ldapStartTLS(conn net.Conn) error {
+	oid := []byte(ldapExtendedRequestOID)
+	requestName := append([]byte{0x80, byte(len(oid))}, oid...)
+	extendedRequest := append([]byte{0x77, byte(len(requestName))}, requestName...)
+	messageID := []byte{0x02, 0x01, 0x01}
+	body := append(append([]byte{}, messageID...), extendedRequest...)
+	message := append([]byte{0x30, byte(len(body))}, body...)
+
+	if _, err := conn.Write(message); err != nil {
+		return fmt.Errorf("sending Start TLS extended request: %w", err)
+	}
+
+	resp := make([]byte, 256)
+	n, err := conn.Read(resp)
+	if err != nil {
+		return fmt.Errorf("reading Start TLS response: %w", err)
+	}
+	// resultCode is a BER ENUMERATED (tag 0x0a); success is the sole
+	// zero-length-encoded value 0x00.
+	if !bytes.Contains(resp[:n], []byte{0x0a, 0x01, 0x00}) {
+		return fmt.Errorf("Start TLS extended request rejected")
+	}
+	return nil
+}
+
+// postgresSSLRequest sends the Postgres wire protocol's SSLRequest message
+// and checks for the single-byte 'S' (supported) reply before the TLS
+// handshake. Used for postgres:5432.
+func postgresSSLRequest(conn net.Conn) error {
+	req := make([]byte, 8)
+	binary.BigEndian.PutUint32(req[0:4], 8)
+	binary.BigEndian.PutUint32(req[4:8], 80877103)
+	if _, err := conn.Write(req); err != nil {
+		return fmt.Errorf("sending SSLRequest: %w", err)
+	}
+
+	resp := make([]byte, 1)
+	if _, err := io.ReadFull(conn, resp); err != nil {
+		return fmt.Errorf("reading SSLRequest response: %w", err)
+	}
+	if resp[0] != 'S' {
+		return fmt.Errorf("server does not support SSL (response %q)", resp[0])
+	}
+	return nil
+}
+
+var tlsVersionsByName = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// parseMinTLSVersion maps an endpoint's MinTLSVersion field ("", "1.0",
+// "1.1", "1.2", "1.3") to the tls.VersionTLS* constant tls.Config expects.
+func parseMinTLSVersion(s string) (uint16, error) {
+	if s == "" {
+		return 0, nil
+	}
+	v, ok := tlsVersionsByName[s]
+	if !ok {
+		return 0, fmt.Errorf("unknown TLS version %q", s)
+	}
+	return v, nil
+}
+
+// parseCipherSuites maps a comma-separated list of cipher suite names (as
+// reported by tls.CipherSuiteName) to the IDs tls.Config expects.
+func parseCipherSuites(s string) ([]uint16, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	byName := make(map[string]uint16)
+	for _, suite := range tls.CipherSuites() {
+		byName[suite.Name] = suite.ID
+	}
+	for _, suite := range tls.InsecureCipherSuites() {
+		byName[suite.Name] = suite.ID
+	}
+
+	var ids []uint16
+	for _, name := range strings.Split(s, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		id, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown cipher suite %q", name)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
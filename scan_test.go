@@ -0,0 +1,145 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+)
+
+// newTestCert generates a certificate from tmpl, self-signed when parent is
+// nil or signed by parent/parentKey otherwise, and returns the parsed
+// certificate plus its private key (so it can in turn sign a child cert).
+func newTestCert(t *testing.T, tmpl *x509.Certificate, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+
+	signerTmpl, signerKey := parent, parentKey
+	if signerTmpl == nil {
+		signerTmpl, signerKey = tmpl, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, signerTmpl, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatalf("creating certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("parsing certificate: %v", err)
+	}
+	return cert, key
+}
+
+func newTestSerial(t *testing.T) *big.Int {
+	t.Helper()
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		t.Fatalf("generating serial: %v", err)
+	}
+	return serial
+}
+
+func TestClassifyCertStatus(t *testing.T) {
+	now := time.Now()
+	const hostname = "leaf.example.com"
+
+	caTemplate := func(notBefore, notAfter time.Time, dnsNames []string) *x509.Certificate {
+		return &x509.Certificate{
+			SerialNumber:          newTestSerial(t),
+			Subject:               pkix.Name{CommonName: "Test CA"},
+			DNSNames:              dnsNames,
+			NotBefore:             notBefore,
+			NotAfter:              notAfter,
+			IsCA:                  true,
+			KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+			BasicConstraintsValid: true,
+		}
+	}
+	leafTemplate := func(notBefore, notAfter time.Time, dnsNames []string) *x509.Certificate {
+		return &x509.Certificate{
+			SerialNumber: newTestSerial(t),
+			Subject:      pkix.Name{CommonName: hostname},
+			DNSNames:     dnsNames,
+			NotBefore:    notBefore,
+			NotAfter:     notAfter,
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+		}
+	}
+	tests := []struct {
+		name  string
+		certs func() []*x509.Certificate
+		want  CertStatus
+	}{
+		{
+			name: "expired leaf takes priority over expired intermediate",
+			certs: func() []*x509.Certificate {
+				ca, caKey := newTestCert(t, caTemplate(now.Add(-48*time.Hour), now.Add(-24*time.Hour), nil), nil, nil)
+				leaf, _ := newTestCert(t, leafTemplate(now.Add(-48*time.Hour), now.Add(-time.Hour), []string{hostname}), ca, caKey)
+				return []*x509.Certificate{leaf, ca}
+			},
+			want: CertStatusExpiredLeaf,
+		},
+		{
+			name: "expired intermediate with a still-valid leaf",
+			certs: func() []*x509.Certificate {
+				ca, caKey := newTestCert(t, caTemplate(now.Add(-48*time.Hour), now.Add(-24*time.Hour), nil), nil, nil)
+				leaf, _ := newTestCert(t, leafTemplate(now.Add(-time.Hour), now.Add(24*time.Hour), []string{hostname}), ca, caKey)
+				return []*x509.Certificate{leaf, ca}
+			},
+			want: CertStatusExpiredIntermediate,
+		},
+		{
+			name: "hostname mismatch is checked before self-signed",
+			certs: func() []*x509.Certificate {
+				leaf, _ := newTestCert(t, leafTemplate(now.Add(-time.Hour), now.Add(24*time.Hour), []string{"other.example.com"}), nil, nil)
+				return []*x509.Certificate{leaf}
+			},
+			want: CertStatusHostnameMismatch,
+		},
+		{
+			// The default shape produced by tooling like "openssl req -x509"
+			// for a self-signed leaf: no IsCA/KeyUsageCertSign/basic
+			// constraints at all. isSelfSigned must not require them.
+			name: "self-signed leaf with no CA basic constraints is reported before unknown issuer",
+			certs: func() []*x509.Certificate {
+				leaf, _ := newTestCert(t, leafTemplate(now.Add(-time.Hour), now.Add(24*time.Hour), []string{hostname}), nil, nil)
+				return []*x509.Certificate{leaf}
+			},
+			want: CertStatusSelfSigned,
+		},
+		{
+			name: "self-signed root with CA basic constraints is also reported as self-signed",
+			certs: func() []*x509.Certificate {
+				ca, _ := newTestCert(t, caTemplate(now.Add(-time.Hour), now.Add(24*time.Hour), []string{hostname}), nil, nil)
+				return []*x509.Certificate{ca}
+			},
+			want: CertStatusSelfSigned,
+		},
+		{
+			name: "valid chain to an untrusted root is unknown issuer",
+			certs: func() []*x509.Certificate {
+				ca, caKey := newTestCert(t, caTemplate(now.Add(-48*time.Hour), now.Add(48*time.Hour), nil), nil, nil)
+				leaf, _ := newTestCert(t, leafTemplate(now.Add(-time.Hour), now.Add(24*time.Hour), []string{hostname}), ca, caKey)
+				return []*x509.Certificate{leaf, ca}
+			},
+			want: CertStatusUnknownIssuer,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := classifyCertStatus(tt.certs(), hostname)
+			if got != tt.want {
+				t.Errorf("classifyCertStatus() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
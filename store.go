@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// Endpoint is a monitored URL persisted in SQLite, replacing the URL_*
+// environment variable list for deployments that run the admin web UI.
+type Endpoint struct {
+	ID         int64
+	Name       string
+	URL        string
+	Protocol   string
+	ServerName string
+
+	// ClientCertFile/ClientKeyFile, when both set, present a client
+	// certificate for mTLS-protected endpoints.
+	ClientCertFile string
+	ClientKeyFile  string
+
+	// MinTLSVersion is one of "", "1.0", "1.1", "1.2", "1.3"; empty means
+	// Go's default. CipherSuites is a comma-separated list of suite names
+	// (as reported by tls.CipherSuiteName), for auditing legacy TLS.
+	MinTLSVersion string
+	CipherSuites  string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Store is a SQLite-backed persistence layer for monitored endpoints.
+type Store struct {
+	db *sql.DB
+}
+
+// NewStore opens (creating if necessary) the SQLite database at path and
+// ensures its schema is up to date.
+func NewStore(path string) (*Store, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("opening database: %w", err)
+	}
+
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("connecting to database: %w", err)
+	}
+
+	if _, err := db.Exec(endpointsSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("applying schema: %w", err)
+	}
+	if err := migrateEndpoints(db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("migrating schema: %w", err)
+	}
+
+	return &Store{db: db}, nil
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+const endpointsSchema = `
+CREATE TABLE IF NOT EXISTS endpoints (
+	id          INTEGER PRIMARY KEY AUTOINCREMENT,
+	name        TEXT NOT NULL UNIQUE,
+	url         TEXT NOT NULL,
+	protocol    TEXT NOT NULL DEFAULT 'https',
+	server_name TEXT NOT NULL DEFAULT '',
+	created_at  DATETIME NOT NULL,
+	updated_at  DATETIME NOT NULL
+);
+`
+
+// endpointColumns are the columns added to the endpoints table after its
+// initial release, applied with ALTER TABLE so existing databases pick them
+// up without losing data. Errors from a column that already exists are
+// ignored, since SQLite has no "ADD COLUMN IF NOT EXISTS".
+var endpointColumns = []string{
+	`ALTER TABLE endpoints ADD COLUMN client_cert_file TEXT NOT NULL DEFAULT ''`,
+	`ALTER TABLE endpoints ADD COLUMN client_key_file TEXT NOT NULL DEFAULT ''`,
+	`ALTER TABLE endpoints ADD COLUMN min_tls_version TEXT NOT NULL DEFAULT ''`,
+	`ALTER TABLE endpoints ADD COLUMN cipher_suites TEXT NOT NULL DEFAULT ''`,
+}
+
+func migrateEndpoints(db *sql.DB) error {
+	for _, stmt := range endpointColumns {
+		if _, err := db.Exec(stmt); err != nil && !strings.Contains(err.Error(), "duplicate column name") {
+			return err
+		}
+	}
+	return nil
+}
+
+const endpointColumnList = `id, name, url, protocol, server_name, client_cert_file, client_key_file, min_tls_version, cipher_suites, created_at, updated_at`
+
+func scanEndpoint(row interface{ Scan(...interface{}) error }) (Endpoint, error) {
+	var e Endpoint
+	err := row.Scan(&e.ID, &e.Name, &e.URL, &e.Protocol, &e.ServerName, &e.ClientCertFile, &e.ClientKeyFile,
+		&e.MinTLSVersion, &e.CipherSuites, &e.CreatedAt, &e.UpdatedAt)
+	return e, err
+}
+
+// List returns every monitored endpoint, ordered by name.
+func (s *Store) List(ctx context.Context) ([]Endpoint, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT `+endpointColumnList+` FROM endpoints ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var endpoints []Endpoint
+	for rows.Next() {
+		e, err := scanEndpoint(rows)
+		if err != nil {
+			return nil, err
+		}
+		endpoints = append(endpoints, e)
+	}
+	return endpoints, rows.Err()
+}
+
+// Get returns a single endpoint by ID.
+func (s *Store) Get(ctx context.Context, id int64) (Endpoint, error) {
+	row := s.db.QueryRowContext(ctx, `SELECT `+endpointColumnList+` FROM endpoints WHERE id = ?`, id)
+	return scanEndpoint(row)
+}
+
+// Create inserts a new monitored endpoint.
+func (s *Store) Create(ctx context.Context, e Endpoint) (Endpoint, error) {
+	now := time.Now()
+	res, err := s.db.ExecContext(ctx,
+		`INSERT INTO endpoints (name, url, protocol, server_name, client_cert_file, client_key_file, min_tls_version, cipher_suites, created_at, updated_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		e.Name, e.URL, e.Protocol, e.ServerName, e.ClientCertFile, e.ClientKeyFile, e.MinTLSVersion, e.CipherSuites, now, now)
+	if err != nil {
+		return Endpoint{}, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return Endpoint{}, err
+	}
+
+	e.ID = id
+	e.CreatedAt = now
+	e.UpdatedAt = now
+	return e, nil
+}
+
+// Update overwrites an existing endpoint's fields.
+func (s *Store) Update(ctx context.Context, e Endpoint) error {
+	now := time.Now()
+	_, err := s.db.ExecContext(ctx,
+		`UPDATE endpoints SET name = ?, url = ?, protocol = ?, server_name = ?, client_cert_file = ?, client_key_file = ?,
+		 min_tls_version = ?, cipher_suites = ?, updated_at = ? WHERE id = ?`,
+		e.Name, e.URL, e.Protocol, e.ServerName, e.ClientCertFile, e.ClientKeyFile, e.MinTLSVersion, e.CipherSuites, now, e.ID)
+	return err
+}
+
+// Delete removes an endpoint by ID.
+func (s *Store) Delete(ctx context.Context, id int64) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM endpoints WHERE id = ?`, id)
+	return err
+}
@@ -0,0 +1,390 @@
+package main
+
+import (
+	"context"
+	"html/template"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// adminStore is set when the admin web UI is enabled, and is consulted by
+// checkCertificates in place of the URL_* environment variables.
+var adminStore *Store
+
+// statusCache holds the most recent CertInfo for each monitored endpoint
+// name, populated by scheduled scans and on-demand rechecks, so the admin
+// dashboard can render instantly without reaching out to every host.
+var statusCache sync.Map // name (string) -> CertInfo
+
+// AdminServer is the OAuth2/OIDC-protected web UI for managing monitored
+// endpoints: add/edit/remove, view current cert status, trigger rechecks.
+type AdminServer struct {
+	Addr  string
+	Store *Store
+	Auth  *Authenticator
+}
+
+// newAdminServerFromEnv builds the admin server if ADMIN_ADDR and DB_PATH
+// are both set. It returns an error (rather than nil, nil) when those are
+// set but the required OIDC/session config is missing, since that's a
+// misconfiguration the operator should fix rather than silently ignore.
+func newAdminServerFromEnv(ctx context.Context) (*AdminServer, error) {
+	addr := os.Getenv("ADMIN_ADDR")
+	dbPath := os.Getenv("DB_PATH")
+	if addr == "" || dbPath == "" {
+		return nil, nil
+	}
+
+	authCfg := newAuthConfigFromEnv()
+	if authCfg == nil {
+		return nil, errAdminAuthRequired
+	}
+
+	store, err := NewStore(dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	authenticator, err := newAuthenticator(ctx, authCfg)
+	if err != nil {
+		store.Close()
+		return nil, err
+	}
+
+	return &AdminServer{Addr: addr, Store: store, Auth: authenticator}, nil
+}
+
+var errAdminAuthRequired = &adminConfigError{"ADMIN_ADDR and DB_PATH are set but OIDC_CLIENT_ID/OIDC_CLIENT_SECRET/SESSION_SECRET are missing"}
+
+type adminConfigError struct{ msg string }
+
+func (e *adminConfigError) Error() string { return e.msg }
+
+// Start registers the admin UI's routes and runs its HTTP server in the
+// background, and makes its store the source of monitored endpoints for
+// scheduled scans.
+func (a *AdminServer) Start() {
+	adminStore = a.Store
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/login", a.Auth.handleLogin)
+	mux.HandleFunc("/oauth/callback", a.Auth.handleCallback)
+	mux.HandleFunc("/logout", a.Auth.handleLogout)
+	mux.HandleFunc("/", a.Auth.requireSession(a.handleDashboard))
+	mux.HandleFunc("/endpoints/new", a.Auth.requireEdit(a.handleNewEndpointForm))
+	mux.HandleFunc("/endpoints/create", a.Auth.requireEdit(a.handleCreateEndpoint))
+	mux.HandleFunc("/endpoints/", a.Auth.requireSession(a.handleEndpointAction))
+
+	server := &http.Server{
+		Addr:         a.Addr,
+		Handler:      mux,
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: 15 * time.Second,
+	}
+
+	go func() {
+		logger.Printf("Admin UI listening on %s", a.Addr)
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logger.Printf("Admin UI server error: %v", err)
+		}
+	}()
+}
+
+// handleEndpointAction dispatches /endpoints/{id}/{action} requests, since
+// net/http's ServeMux here has no path-parameter support.
+func (a *AdminServer) handleEndpointAction(w http.ResponseWriter, r *http.Request, session Session) {
+	rest := strings.TrimPrefix(r.URL.Path, "/endpoints/")
+	parts := strings.SplitN(rest, "/", 2)
+	id, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	action := ""
+	if len(parts) == 2 {
+		action = parts[1]
+	}
+
+	// update/delete/recheck mutate state and must only be reachable via
+	// POST, since a plain GET link, meta-refresh, or crawler would
+	// otherwise trigger them using an authenticated admin's session.
+	if action == "update" || action == "delete" || action == "recheck" {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+	}
+
+	switch action {
+	case "edit":
+		a.requireEditOr403(w, r, session, func() { a.handleEditEndpointForm(w, r, id) })
+	case "update":
+		a.requireEditOr403(w, r, session, func() { a.handleUpdateEndpoint(w, r, id) })
+	case "delete":
+		a.requireEditOr403(w, r, session, func() { a.handleDeleteEndpoint(w, r, id) })
+	case "recheck":
+		a.handleRecheckEndpoint(w, r, id)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (a *AdminServer) requireEditOr403(w http.ResponseWriter, r *http.Request, session Session, next func()) {
+	if !session.CanEdit(a.Auth.cfg.EditGroup) {
+		http.Error(w, "forbidden: missing edit group membership", http.StatusForbidden)
+		return
+	}
+	next()
+}
+
+type dashboardEndpoint struct {
+	Endpoint
+	Status CertInfo
+	Known  bool
+}
+
+func (a *AdminServer) handleDashboard(w http.ResponseWriter, r *http.Request, session Session) {
+	endpoints, err := a.Store.List(r.Context())
+	if err != nil {
+		http.Error(w, "failed to list endpoints", http.StatusInternalServerError)
+		return
+	}
+
+	rows := make([]dashboardEndpoint, 0, len(endpoints))
+	for _, e := range endpoints {
+		row := dashboardEndpoint{Endpoint: e}
+		if cached, ok := statusCache.Load(e.Name); ok {
+			row.Status = cached.(CertInfo)
+			row.Known = true
+		}
+		rows = append(rows, row)
+	}
+
+	renderTemplate(w, dashboardTemplate, struct {
+		Session   Session
+		CanEdit   bool
+		Endpoints []dashboardEndpoint
+	}{Session: session, CanEdit: session.CanEdit(a.Auth.cfg.EditGroup), Endpoints: rows})
+}
+
+func (a *AdminServer) handleNewEndpointForm(w http.ResponseWriter, r *http.Request, session Session) {
+	renderTemplate(w, endpointFormTemplate, struct {
+		Title    string
+		Action   string
+		Endpoint Endpoint
+	}{Title: "Add endpoint", Action: "/endpoints/create"})
+}
+
+func (a *AdminServer) handleCreateEndpoint(w http.ResponseWriter, r *http.Request, session Session) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "invalid form", http.StatusBadRequest)
+		return
+	}
+
+	_, err := a.Store.Create(r.Context(), endpointFromForm(r))
+	if err != nil {
+		http.Error(w, "failed to create endpoint: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	http.Redirect(w, r, "/", http.StatusFound)
+}
+
+func (a *AdminServer) handleEditEndpointForm(w http.ResponseWriter, r *http.Request, id int64) {
+	endpoint, err := a.Store.Get(r.Context(), id)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	renderTemplate(w, endpointFormTemplate, struct {
+		Title    string
+		Action   string
+		Endpoint Endpoint
+	}{Title: "Edit endpoint", Action: "/endpoints/" + strconv.FormatInt(id, 10) + "/update", Endpoint: endpoint})
+}
+
+func (a *AdminServer) handleUpdateEndpoint(w http.ResponseWriter, r *http.Request, id int64) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "invalid form", http.StatusBadRequest)
+		return
+	}
+
+	endpoint := endpointFromForm(r)
+	endpoint.ID = id
+	if err := a.Store.Update(r.Context(), endpoint); err != nil {
+		http.Error(w, "failed to update endpoint: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	http.Redirect(w, r, "/", http.StatusFound)
+}
+
+func (a *AdminServer) handleDeleteEndpoint(w http.ResponseWriter, r *http.Request, id int64) {
+	if err := a.Store.Delete(r.Context(), id); err != nil {
+		http.Error(w, "failed to delete endpoint: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/", http.StatusFound)
+}
+
+func (a *AdminServer) handleRecheckEndpoint(w http.ResponseWriter, r *http.Request, id int64) {
+	endpoint, err := a.Store.Get(r.Context(), id)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	target, err := scanTargetFromEndpoint(endpoint)
+	if err != nil {
+		logger.Printf("On-demand recheck of %s (%s) failed: %v", endpoint.Name, endpoint.URL, err)
+		http.Redirect(w, r, "/", http.StatusFound)
+		return
+	}
+
+	cfg := scanConfigFromEnv()
+	certInfo, err := dialAndInspect(r.Context(), target, cfg.Timeout)
+	if err != nil {
+		logger.Printf("On-demand recheck of %s (%s) failed: %v", endpoint.Name, endpoint.URL, err)
+	} else {
+		statusCache.Store(endpoint.Name, certInfo)
+	}
+
+	http.Redirect(w, r, "/", http.StatusFound)
+}
+
+func endpointFromForm(r *http.Request) Endpoint {
+	return Endpoint{
+		Name:           strings.TrimSpace(r.FormValue("name")),
+		URL:            strings.TrimSpace(r.FormValue("url")),
+		Protocol:       orDefault(strings.TrimSpace(r.FormValue("protocol")), "https"),
+		ServerName:     strings.TrimSpace(r.FormValue("server_name")),
+		ClientCertFile: strings.TrimSpace(r.FormValue("client_cert_file")),
+		ClientKeyFile:  strings.TrimSpace(r.FormValue("client_key_file")),
+		MinTLSVersion:  strings.TrimSpace(r.FormValue("min_tls_version")),
+		CipherSuites:   strings.TrimSpace(r.FormValue("cipher_suites")),
+	}
+}
+
+// scanTargetFromEndpoint translates a stored Endpoint into the ScanTarget
+// the worker pool in scan.go dials, validating its MinTLSVersion/
+// CipherSuites overrides.
+func scanTargetFromEndpoint(e Endpoint) (ScanTarget, error) {
+	minVersion, err := parseMinTLSVersion(e.MinTLSVersion)
+	if err != nil {
+		return ScanTarget{}, err
+	}
+	cipherSuites, err := parseCipherSuites(e.CipherSuites)
+	if err != nil {
+		return ScanTarget{}, err
+	}
+
+	return ScanTarget{
+		Name:           e.Name,
+		URL:            e.URL,
+		Protocol:       e.Protocol,
+		ServerName:     e.ServerName,
+		ClientCertFile: e.ClientCertFile,
+		ClientKeyFile:  e.ClientKeyFile,
+		MinVersion:     minVersion,
+		CipherSuites:   cipherSuites,
+	}, nil
+}
+
+// loadTargetsFromStore builds the scan targets checkCertificates probes,
+// from the admin UI's SQLite store, for deployments that have migrated off
+// the URL_* environment variables. An endpoint with an invalid
+// MinTLSVersion/CipherSuites override is logged and skipped rather than
+// failing the whole scan.
+func loadTargetsFromStore(ctx context.Context, store *Store) ([]ScanTarget, error) {
+	endpoints, err := store.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	targets := make([]ScanTarget, 0, len(endpoints))
+	for _, e := range endpoints {
+		target, err := scanTargetFromEndpoint(e)
+		if err != nil {
+			logger.Printf("Skipping %s (%s): %v", e.Name, e.URL, err)
+			continue
+		}
+		targets = append(targets, target)
+	}
+	return targets, nil
+}
+
+func renderTemplate(w http.ResponseWriter, tmpl *template.Template, data interface{}) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := tmpl.Execute(w, data); err != nil {
+		logger.Printf("Error rendering template: %v", err)
+	}
+}
+
+var dashboardTemplate = template.Must(template.New("dashboard").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="UTF-8"><title>SSL Certificate Monitor - Admin</title></head>
+<body>
+	<h1>SSL Certificate Monitor</h1>
+	<p>Signed in as {{.Session.Email}} &middot; <a href="/logout">Log out</a></p>
+	{{if .CanEdit}}<p><a href="/endpoints/new">Add endpoint</a></p>{{end}}
+	<table border="1" cellpadding="6" cellspacing="0">
+		<tr>
+			<th>Name</th><th>URL</th><th>Protocol</th><th>Status</th><th>Days left</th><th>Actions</th>
+		</tr>
+		{{range .Endpoints}}
+		<tr>
+			<td>{{.Name}}</td>
+			<td>{{.URL}}</td>
+			<td>{{.Protocol}}</td>
+			{{if .Known}}
+			<td>{{.Status.CommonName}}</td>
+			<td>{{.Status.DaysRemaining}}</td>
+			{{else}}
+			<td colspan="2">not yet checked</td>
+			{{end}}
+			<td>
+				<form action="/endpoints/{{.ID}}/recheck" method="post" style="display:inline">
+					<button type="submit">Recheck</button>
+				</form>
+				{{if $.CanEdit}}
+				<a href="/endpoints/{{.ID}}/edit">Edit</a>
+				<form action="/endpoints/{{.ID}}/delete" method="post" style="display:inline">
+					<button type="submit" onclick="return confirm('Delete this endpoint?')">Delete</button>
+				</form>
+				{{end}}
+			</td>
+		</tr>
+		{{end}}
+	</table>
+</body>
+</html>`))
+
+var endpointFormTemplate = template.Must(template.New("endpointForm").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="UTF-8"><title>{{.Title}} - SSL Certificate Monitor</title></head>
+<body>
+	<h1>{{.Title}}</h1>
+	<form action="{{.Action}}" method="post">
+		<label>Name <input type="text" name="name" value="{{.Endpoint.Name}}" required></label><br>
+		<label>URL <input type="text" name="url" value="{{.Endpoint.URL}}" required></label><br>
+		<label>Protocol <input type="text" name="protocol" value="{{.Endpoint.Protocol}}" placeholder="https"></label><br>
+		<label>SNI / ServerName override <input type="text" name="server_name" value="{{.Endpoint.ServerName}}"></label><br>
+		<label>Client cert file (mTLS) <input type="text" name="client_cert_file" value="{{.Endpoint.ClientCertFile}}"></label><br>
+		<label>Client key file (mTLS) <input type="text" name="client_key_file" value="{{.Endpoint.ClientKeyFile}}"></label><br>
+		<label>Minimum TLS version <input type="text" name="min_tls_version" value="{{.Endpoint.MinTLSVersion}}" placeholder="1.2"></label><br>
+		<label>Cipher suites (comma-separated) <input type="text" name="cipher_suites" value="{{.Endpoint.CipherSuites}}"></label><br>
+		<button type="submit">Save</button>
+	</form>
+	<p><a href="/">Back</a></p>
+</body>
+</html>`))